@@ -1,6 +1,7 @@
 package gojay
 
 import (
+	"encoding"
 	"fmt"
 	"io"
 	"reflect"
@@ -16,8 +17,11 @@ func UnmarshalArray(data []byte, v UnmarshalerArray) error {
 	dec := newDecoder(nil, 0)
 	dec.data = data
 	dec.length = len(data)
+	defer dec.addToPool()
+	if err := dec.expectKind(KindArray); err != nil {
+		return err
+	}
 	_, err := dec.DecodeArray(v)
-	dec.addToPool()
 	if err != nil {
 		return err
 	}
@@ -37,8 +41,11 @@ func UnmarshalObject(data []byte, v UnmarshalerObject) error {
 	dec := newDecoder(nil, 0)
 	dec.data = data
 	dec.length = len(data)
+	defer dec.addToPool()
+	if err := dec.expectKind(KindObject); err != nil {
+		return err
+	}
 	_, err := dec.DecodeObject(v)
-	dec.addToPool()
 	if err != nil {
 		return err
 	}
@@ -48,6 +55,40 @@ func UnmarshalObject(data []byte, v UnmarshalerObject) error {
 	return nil
 }
 
+// DecodeObjectPrefix parses the JSON object found at the start of data, ignoring whatever follows it,
+// and stores the result in the value pointed to by v.
+//
+// It returns the number of bytes consumed, leading whitespaces and the object included,
+// so that data[consumed:] is what follows the object.
+// As with UnmarshalObject, the bytes of the object may be modified while decoding, the bytes following it are not.
+func DecodeObjectPrefix(data []byte, v UnmarshalerObject) (int, error) {
+	dec := newDecoder(nil, 0)
+	dec.data = data
+	dec.length = len(data)
+	defer dec.addToPool()
+	if err := dec.expectKind(KindObject); err != nil {
+		return 0, err
+	}
+	// find the end of the object first, so that decoding does not touch the bytes following it
+	if err := dec.skipData(); err != nil {
+		return 0, err
+	}
+	end := dec.cursor
+	if end == 0 {
+		return 0, InvalidJSONError("Invalid JSON while parsing object")
+	}
+	dec.data = data[:end:end]
+	dec.length = end
+	dec.cursor = 0
+	if _, err := dec.DecodeObject(v); err != nil {
+		return 0, err
+	}
+	if dec.err != nil {
+		return end, dec.err
+	}
+	return end, nil
+}
+
 // Unmarshal parses the JSON-encoded data and stores the result in the value pointed to by v.
 // If v is nil, not a pointer, or not an implementation of UnmarshalerObject or UnmarshalerArray
 // Unmarshal returns an InvalidUnmarshalError.
@@ -62,6 +103,9 @@ func UnmarshalObject(data []byte, v UnmarshalerObject) error {
 //
 // To unmarshal a JSON array into a slice, Unmarshal requires the slice to implement UnmarshalerArray.
 //
+// A JSON string can be unmarshaled into any value implementing encoding.TextUnmarshaler.
+// A base64 JSON string, as Marshal encodes a []byte, can be unmarshaled into a *[]byte.
+//
 // Unmarshal JSON does not allow yet to unmarshall an interface value
 // If a JSON value is not appropriate for a given target type, or if a JSON number
 // overflows the target type, Unmarshal skips that field and completes the unmarshaling as best it can.
@@ -110,16 +154,30 @@ func Unmarshal(data []byte, v interface{}) error {
 		dec.length = len(data)
 		dec.data = data
 		err = dec.DecodeBool(vt)
+	case *[]byte:
+		dec = newDecoder(nil, 0)
+		dec.length = len(data)
+		dec.data = data
+		err = dec.DecodeBytes(vt)
 	case UnmarshalerObject:
 		dec = newDecoder(nil, 0)
 		dec.length = len(data)
 		dec.data = data
-		_, err = dec.DecodeObject(vt)
+		if err = dec.expectKind(KindObject); err == nil {
+			_, err = dec.DecodeObject(vt)
+		}
 	case UnmarshalerArray:
 		dec = newDecoder(nil, 0)
 		dec.length = len(data)
 		dec.data = data
-		_, err = dec.DecodeArray(vt)
+		if err = dec.expectKind(KindArray); err == nil {
+			_, err = dec.DecodeArray(vt)
+		}
+	case encoding.TextUnmarshaler:
+		dec = newDecoder(nil, 0)
+		dec.length = len(data)
+		dec.data = data
+		err = dec.DecodeTextUnmarshaler(vt)
 	default:
 		return InvalidUnmarshalError(fmt.Sprintf(invalidUnmarshalErrorMsg, reflect.TypeOf(vt).String()))
 	}
@@ -159,6 +217,25 @@ type Decoder struct {
 	child    byte
 	err      error
 	r        io.Reader
+
+	fieldHook     func(key string, start, end int)
+	maxValueBytes int
+	maxExponent   int
+	migrator      func(key string, dec *Decoder) (string, bool, error)
+	keyTransform  func(string) string
+
+	continueOnError bool
+	fieldErrors     []*FieldError
+}
+
+// SetMaxValueBytes sets the maximum number of bytes a single object field value or array element may span
+// in the JSON input, 0 meaning no limit.
+//
+// The size of a value is checked once the value has been read, nested values are checked
+// before the value containing them. If a value exceeds n bytes, decoding stops
+// and a LimitExceededError is returned.
+func (dec *Decoder) SetMaxValueBytes(n int) {
+	dec.maxValueBytes = n
 }
 
 // Decode reads the next JSON-encoded value from its input and stores it in the value pointed to by v.
@@ -182,12 +259,22 @@ func (dec *Decoder) Decode(v interface{}) error {
 		return dec.DecodeFloat64(vt)
 	case *bool:
 		return dec.DecodeBool(vt)
+	case *[]byte:
+		return dec.DecodeBytes(vt)
 	case UnmarshalerObject:
+		if err := dec.expectKind(KindObject); err != nil {
+			return err
+		}
 		_, err := dec.DecodeObject(vt)
 		return err
 	case UnmarshalerArray:
+		if err := dec.expectKind(KindArray); err != nil {
+			return err
+		}
 		_, err := dec.DecodeArray(vt)
 		return err
+	case encoding.TextUnmarshaler:
+		return dec.DecodeTextUnmarshaler(vt)
 	default:
 		return InvalidUnmarshalError(fmt.Sprintf(invalidUnmarshalErrorMsg, reflect.TypeOf(vt).String()))
 	}
@@ -217,6 +304,17 @@ func (dec *Decoder) AddFloat(v *float64) error {
 	return nil
 }
 
+// AddFloatWithUnit decodes the next key, a string made of a number followed by unit such as "12.5ms", to a *float64.
+// If the string does not end with unit or its remaining part is not a number, an InvalidTypeError will be returned.
+func (dec *Decoder) AddFloatWithUnit(v *float64, unit string) error {
+	err := dec.DecodeFloatWithUnit(v, unit)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
 // AddBool decodes the next key to a *bool.
 // If next key is neither null nor a JSON boolean, an InvalidTypeError will be returned.
 // If next key is null, bool will be false.
@@ -240,6 +338,17 @@ func (dec *Decoder) AddString(v *string) error {
 	return nil
 }
 
+// AddStringURLEncoded decodes the next key, a percent-encoded string, to a *string.
+// If next key is not a JSON string nor null, or is not a valid percent-encoded string, InvalidTypeError will be returned.
+func (dec *Decoder) AddStringURLEncoded(v *string) error {
+	err := dec.DecodeStringURLEncoded(v)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
 // AddObject decodes the next key to a UnmarshalerObject.
 func (dec *Decoder) AddObject(value UnmarshalerObject) error {
 	initialKeysDone := dec.keysDone
@@ -282,6 +391,20 @@ func isDigit(b byte) bool {
 
 func (dec *Decoder) read() bool {
 	if dec.r != nil {
+		// if buffer is full, grow it so that values bigger
+		// than the initial buffer can be read, a buffer reused from the pool may already have the capacity
+		if dec.length == len(dec.data) {
+			if dec.length < cap(dec.data) {
+				dec.data = dec.data[:cap(dec.data)]
+			} else {
+				Buf := make([]byte, dec.length, 2*len(dec.data)+512)
+				copy(Buf, dec.data)
+				dec.data = Buf[:cap(Buf)]
+				if m := metrics(); m != nil {
+					m.BufferGrow(PoolDecoder, cap(Buf))
+				}
+			}
+		}
 		// idea is to append data from reader at the end
 		n, err := dec.r.Read(dec.data[dec.length:])
 		if err != nil || n == 0 {
@@ -293,6 +416,47 @@ func (dec *Decoder) read() bool {
 	return false
 }
 
+func (dec *Decoder) valueLimitError(start int) error {
+	return LimitExceededError(
+		fmt.Sprintf(
+			"Value starting at pos %d exceeds the limit of %d bytes",
+			start,
+			dec.maxValueBytes,
+		),
+	)
+}
+
+// expectKind checks the kind of the next JSON value, without consuming it.
+// It returns a TypeMismatchError if the value is neither of kind k nor null,
+// invalid values are left to the decoding methods.
+func (dec *Decoder) expectKind(k Kind) error {
+	c := dec.nextChar()
+	if actual := kindOf(c); actual != 0 && actual != k && actual != KindNull {
+		return &TypeMismatchError{
+			Expected: k,
+			Actual:   actual,
+			Offset:   dec.cursor,
+		}
+	}
+	return nil
+}
+
+// assertNull consumes the null literal at the cursor, an InvalidJSONError is returned if it is not null.
+func (dec *Decoder) assertNull() error {
+	return dec.walkLiteral("null")
+}
+
+// advance moves the cursor n bytes forward, reading from the reader if needed.
+func (dec *Decoder) advance(n int) error {
+	for i := 0; i < n; i++ {
+		if !(dec.cursor < dec.length || dec.read()) {
+			return InvalidJSONError("Invalid JSON, unexpected end of input")
+		}
+		dec.cursor = dec.cursor + 1
+	}
+	return nil
+}
+
 func (dec *Decoder) nextChar() byte {
 	for dec.cursor < dec.length || dec.read() {
 		switch dec.data[dec.cursor] {
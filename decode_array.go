@@ -27,10 +27,14 @@ func (dec *Decoder) DecodeArray(arr UnmarshalerArray) (int, error) {
 					return dec.cursor, nil
 				}
 				// calling unmarshall function for each element of the slice
+				start := dec.cursor
 				err := arr.UnmarshalArray(dec)
 				if err != nil {
 					return 0, err
 				}
+				if dec.maxValueBytes > 0 && dec.cursor-start > dec.maxValueBytes {
+					return 0, dec.valueLimitError(start)
+				}
 				n++
 			}
 			return dec.cursor, nil
@@ -60,11 +64,243 @@ func (dec *Decoder) DecodeArray(arr UnmarshalerArray) (int, error) {
 	return 0, InvalidJSONError("Invalid JSON")
 }
 
+// Tuple reads the next JSON array from its input and applies each handler
+// to the element at the corresponding position.
+//
+// Each handler must decode its element, for example by calling dec.AddInt.
+// If the array does not have exactly as many elements as handlers, an InvalidTypeError is returned.
+// If the JSON value is null, no handler is called.
+func (dec *Decoder) Tuple(handlers ...func(*Decoder) error) error {
+	switch dec.nextChar() {
+	case '[':
+		t := &tuple{handlers: handlers}
+		if _, err := dec.DecodeArray(t); err != nil {
+			return err
+		}
+		if t.n != len(handlers) {
+			return InvalidTypeError(
+				fmt.Sprintf(
+					"Cannot unmarshal to tuple, expected %d elements got %d",
+					len(handlers),
+					t.n,
+				),
+			)
+		}
+		return nil
+	default:
+		return dec.decodeArray(&tuple{})
+	}
+}
+
+// DecodeObjectArray reads the next JSON array from its input and decodes each of its elements
+// to a new UnmarshalerObject returned by newElem, then passes it to appendTo.
+//
+// null elements are skipped without calling newElem.
+// If an element is not an object, the error is returned and no more element is decoded.
+func (dec *Decoder) DecodeObjectArray(newElem func() UnmarshalerObject, appendTo func(UnmarshalerObject)) error {
+	return dec.decodeArray(&objectArray{newElem, appendTo})
+}
+
+// DecodeObjectArrayDedup reads the next JSON array from its input and decodes each of its elements
+// as DecodeObjectArray does, but only passes to appendTo the elements whose identity key,
+// as returned by keyFn, has not been seen before in the array.
+func (dec *Decoder) DecodeObjectArrayDedup(
+	keyFn func(UnmarshalerObject) string,
+	newElem func() UnmarshalerObject,
+	appendTo func(UnmarshalerObject),
+) error {
+	return dec.DecodeObjectArrayDedupWith(make(map[string]struct{}), keyFn, newElem, appendTo)
+}
+
+// DecodeObjectArrayDedupWith is like DecodeObjectArrayDedup but records the identity keys seen in seen,
+// so that the caller can size the set and deduplicate across several arrays.
+// Keys already in seen are considered as seen.
+func (dec *Decoder) DecodeObjectArrayDedupWith(
+	seen map[string]struct{},
+	keyFn func(UnmarshalerObject) string,
+	newElem func() UnmarshalerObject,
+	appendTo func(UnmarshalerObject),
+) error {
+	return dec.DecodeObjectArray(newElem, func(elem UnmarshalerObject) {
+		k := keyFn(elem)
+		if _, ok := seen[k]; ok {
+			return
+		}
+		seen[k] = struct{}{}
+		appendTo(elem)
+	})
+}
+
+// PolymorphicArray reads the next JSON array from its input and decodes each of its elements,
+// objects discriminated by the string value of their key typeKey, to the UnmarshalerObject returned by factory
+// for that value, then passes it to collect.
+//
+// The discriminator is looked up before the element is decoded, so it does not have to be its first key:
+// the decoder then goes back to the start of the element to decode it fully. factory is called with
+// an empty string if the element has no typeKey, the element is skipped if factory returns nil.
+// null elements are skipped without calling factory. If an element is not an object or its discriminator
+// is not a string, the error is returned and no more element is decoded.
+func (dec *Decoder) PolymorphicArray(
+	typeKey string,
+	factory func(typeName string) UnmarshalerObject,
+	collect func(UnmarshalerObject),
+) error {
+	return dec.decodeArray(&polymorphicArray{typeKey, factory, collect})
+}
+
+// polymorphicArray is an UnmarshalerArray decoding each element to the object returned by factory for its discriminator
+type polymorphicArray struct {
+	typeKey string
+	factory func(string) UnmarshalerObject
+	collect func(UnmarshalerObject)
+}
+
+func (a *polymorphicArray) UnmarshalArray(dec *Decoder) error {
+	switch dec.nextChar() {
+	case 'n':
+		return dec.advance(4)
+	case '{':
+	default:
+		err := InvalidTypeError(
+			fmt.Sprintf(
+				"Cannot unmarshal to polymorphic element, wrong char '%s' found at pos %d",
+				string(dec.data[dec.cursor]),
+				dec.cursor,
+			),
+		)
+		if skipErr := dec.skipData(); skipErr != nil {
+			return skipErr
+		}
+		return err
+	}
+	sp := dec.savepoint(dec.cursor)
+	typeName, err := dec.findDiscriminator(a.typeKey)
+	if err != nil {
+		return err
+	}
+	dec.restore(sp)
+	elem := a.factory(typeName)
+	if elem == nil {
+		return dec.skipData()
+	}
+	prevErr := dec.err
+	if err := dec.AddObject(elem); err != nil {
+		return err
+	}
+	if dec.err != prevErr {
+		return dec.err
+	}
+	a.collect(elem)
+	return nil
+}
+
+// findDiscriminator returns a copy of the string value of key in the object starting at the cursor, or "" if it is missing or null.
+//
+// The object is scanned without unescaping its strings in place, so that it can be decoded again from the start.
+func (dec *Decoder) findDiscriminator(key string) (string, error) {
+	dec.cursor = dec.cursor + 1
+	for {
+		switch dec.nextChar() {
+		case '}':
+			return "", nil
+		case '"':
+		default:
+			return "", InvalidJSONError("Invalid JSON while parsing object")
+		}
+		start := dec.cursor + 1
+		dec.cursor = start
+		if err := dec.skipString(); err != nil {
+			return "", err
+		}
+		k := dec.data[start : dec.cursor-1]
+		if dec.nextChar() != ':' {
+			return "", InvalidJSONError("Invalid JSON while parsing object, expected a colon")
+		}
+		dec.cursor = dec.cursor + 1
+		if string(k) != key {
+			if err := dec.skipData(); err != nil {
+				return "", err
+			}
+			continue
+		}
+		switch c := dec.nextChar(); c {
+		case 'n':
+			return "", nil
+		case '"':
+			start = dec.cursor
+			dec.cursor = dec.cursor + 1
+			if err := dec.skipString(); err != nil {
+				return "", err
+			}
+			// the value is decoded from a copy, leaving the object as it was
+			sub := newDecoder(nil, 0)
+			defer sub.addToPool()
+			sub.data = append([]byte(nil), dec.data[start:dec.cursor]...)
+			sub.length = len(sub.data)
+			var v string
+			if err := sub.DecodeString(&v); err != nil {
+				return "", err
+			}
+			return v, nil
+		default:
+			return "", InvalidTypeError(
+				fmt.Sprintf(
+					"Cannot unmarshall to string, wrong char '%s' found at pos %d",
+					string(c),
+					dec.cursor,
+				),
+			)
+		}
+	}
+}
+
+// objectArray is an UnmarshalerArray decoding each element to a new object
+type objectArray struct {
+	newElem  func() UnmarshalerObject
+	appendTo func(UnmarshalerObject)
+}
+
+func (a *objectArray) UnmarshalArray(dec *Decoder) error {
+	if dec.nextChar() == 'n' {
+		return dec.advance(4)
+	}
+	prevErr := dec.err
+	elem := a.newElem()
+	if err := dec.AddObject(elem); err != nil {
+		return err
+	}
+	if dec.err != prevErr {
+		return dec.err
+	}
+	a.appendTo(elem)
+	return nil
+}
+
+// tuple is an UnmarshalerArray calling a different handler for each position
+type tuple struct {
+	handlers []func(*Decoder) error
+	n        int
+}
+
+func (t *tuple) UnmarshalArray(dec *Decoder) error {
+	if t.n >= len(t.handlers) {
+		return InvalidTypeError(
+			fmt.Sprintf(
+				"Cannot unmarshal to tuple, expected %d elements got more",
+				len(t.handlers),
+			),
+		)
+	}
+	err := t.handlers[t.n](dec)
+	t.n++
+	return err
+}
+
 func (dec *Decoder) skipArray() (int, error) {
 	var arraysOpen = 1
 	var arraysClosed = 0
 	// var stringOpen byte = 0
-	for j := dec.cursor; j < dec.length; j++ {
+	for j := dec.cursor; j < dec.length || dec.read(); j++ {
 		switch dec.data[j] {
 		case ']':
 			arraysClosed++
@@ -77,7 +313,7 @@ func (dec *Decoder) skipArray() (int, error) {
 			arraysOpen++
 		case '"':
 			j++
-			for ; j < dec.length; j++ {
+			for ; j < dec.length || dec.read(); j++ {
 				if dec.data[j] != '"' {
 					continue
 				}
@@ -87,7 +323,7 @@ func (dec *Decoder) skipArray() (int, error) {
 				// loop backward and count how many anti slash found
 				// to see if string is effectively escaped
 				ct := 1
-				for i := j; i > 0; i-- {
+				for i := j - 2; i > 0; i-- {
 					if dec.data[i] != '\\' {
 						break
 					}
@@ -102,5 +338,5 @@ func (dec *Decoder) skipArray() (int, error) {
 			continue
 		}
 	}
-	return 0, nil
+	return 0, InvalidJSONError("Invalid JSON while skipping array")
 }
@@ -0,0 +1,82 @@
+package gojay
+
+// FoldFloatArray reads the next JSON array of numbers from its input and folds each element
+// into an accumulator, starting from init, by calling fn. It returns the final accumulator.
+//
+// Elements are discarded once folded, null elements are ignored.
+// If an element is not a number, an InvalidTypeError is returned.
+func (dec *Decoder) FoldFloatArray(init float64, fn func(acc, elem float64) float64) (float64, error) {
+	f := &floatFold{acc: init, fn: fn}
+	if err := dec.decodeArray(f); err != nil {
+		return init, err
+	}
+	return f.acc, nil
+}
+
+// FoldIntArray reads the next JSON array of numbers from its input and folds each element
+// into an accumulator, starting from init, by calling fn. It returns the final accumulator.
+//
+// Elements are discarded once folded, null elements are ignored.
+// If an element is not a number or overflows int, an InvalidTypeError is returned.
+func (dec *Decoder) FoldIntArray(init int, fn func(acc, elem int) int) (int, error) {
+	f := &intFold{acc: init, fn: fn}
+	if err := dec.decodeArray(f); err != nil {
+		return init, err
+	}
+	return f.acc, nil
+}
+
+type floatFold struct {
+	acc float64
+	fn  func(acc, elem float64) float64
+}
+
+func (f *floatFold) UnmarshalArray(dec *Decoder) error {
+	var v float64
+	isNull := dec.data[dec.cursor] == 'n'
+	prevErr := dec.err
+	if err := dec.DecodeFloat64(&v); err != nil {
+		return err
+	}
+	if dec.err != prevErr {
+		return dec.err
+	}
+	if !isNull {
+		f.acc = f.fn(f.acc, v)
+	}
+	return nil
+}
+
+type intFold struct {
+	acc int
+	fn  func(acc, elem int) int
+}
+
+func (f *intFold) UnmarshalArray(dec *Decoder) error {
+	var v int
+	isNull := dec.data[dec.cursor] == 'n'
+	prevErr := dec.err
+	if err := dec.DecodeInt(&v); err != nil {
+		return err
+	}
+	if dec.err != prevErr {
+		return dec.err
+	}
+	if !isNull {
+		f.acc = f.fn(f.acc, v)
+	}
+	return nil
+}
+
+// decodeArray decodes the next JSON array to arr,
+// it returns the error set on the decoder if the JSON value is not an array.
+func (dec *Decoder) decodeArray(arr UnmarshalerArray) error {
+	prevErr := dec.err
+	if _, err := dec.DecodeArray(arr); err != nil {
+		return err
+	}
+	if dec.err != prevErr {
+		return dec.err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package gojay
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoderFoldFloatArray(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		init     float64
+		fn       func(acc, elem float64) float64
+		expected float64
+		errType  interface{}
+	}{
+		{
+			name:     "sum",
+			json:     `[1.5, 2, -3.5, null, 10]`,
+			fn:       func(acc, elem float64) float64 { return acc + elem },
+			expected: 10,
+		},
+		{
+			name:     "max",
+			json:     `[1.5, 20.25, -3.5, 10]`,
+			init:     math.Inf(-1),
+			fn:       math.Max,
+			expected: 20.25,
+		},
+		{
+			name:     "empty",
+			json:     `[]`,
+			init:     1,
+			fn:       func(acc, elem float64) float64 { return acc + elem },
+			expected: 1,
+		},
+		{
+			name:    "invalid-element",
+			json:    `[1, "2", 3]`,
+			fn:      func(acc, elem float64) float64 { return acc + elem },
+			errType: InvalidTypeError(""),
+		},
+		{
+			name:    "not-an-array",
+			json:    `{"test":1}`,
+			fn:      func(acc, elem float64) float64 { return acc + elem },
+			errType: InvalidTypeError(""),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(testCase.json))
+			defer dec.addToPool()
+			r, err := dec.FoldFloatArray(testCase.init, testCase.fn)
+			if testCase.errType != nil {
+				assert.NotNil(t, err, "err should not be nil")
+				assert.IsType(t, testCase.errType, err, "err should be of the expected type")
+				return
+			}
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expected, r, "result should be equal to the expected one")
+		})
+	}
+}
+
+func TestDecoderFoldIntArray(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`[1, 2, -3, null, 10]`))
+	defer dec.addToPool()
+	count, err := dec.FoldIntArray(0, func(acc, elem int) int { return acc + 1 })
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 4, count, "count should be 4")
+
+	dec = NewDecoder(strings.NewReader(`[1, 2, -3, 10]`))
+	defer dec.addToPool()
+	sum, err := dec.FoldIntArray(0, func(acc, elem int) int { return acc + elem })
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 10, sum, "sum should be 10")
+
+	dec = NewDecoder(strings.NewReader(`[1, true]`))
+	defer dec.addToPool()
+	_, err = dec.FoldIntArray(0, func(acc, elem int) int { return acc + elem })
+	assert.NotNil(t, err, "err should not be nil")
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+}
@@ -0,0 +1,257 @@
+package gojay
+
+import (
+	"fmt"
+	"hash"
+	"sync"
+)
+
+// ArrayRawParallel reads the next JSON array from its input and calls cb with the raw bytes of each of its elements.
+//
+// Elements are split sequentially by the decoder, only the calls to cb are dispatched
+// to a pool of workers goroutines, so cb must be safe for concurrent use.
+// raw is a copy of the element owned by cb, it can be decoded in place, modified or retained.
+//
+// It returns the first error returned by cb or encountered while splitting the array.
+// Once an error is returned by cb, no more element is dispatched.
+func (dec *Decoder) ArrayRawParallel(workers int, cb func(raw []byte) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+	var mux sync.Mutex
+	var cbErr error
+	var wg sync.WaitGroup
+	jobs := make(chan []byte, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for raw := range jobs {
+				if err := cb(raw); err != nil {
+					mux.Lock()
+					if cbErr == nil {
+						cbErr = err
+					}
+					mux.Unlock()
+				}
+			}
+		}()
+	}
+	err := dec.rawArray(func(start, end int) error {
+		mux.Lock()
+		err := cbErr
+		mux.Unlock()
+		if err != nil {
+			return err
+		}
+		// the element is copied as cb may decode it, unescaping strings in place, while the decoder keeps reading its buffer
+		jobs <- append([]byte(nil), dec.data[start:end]...)
+		return nil
+	})
+	close(jobs)
+	wg.Wait()
+	if cbErr != nil {
+		return cbErr
+	}
+	return err
+}
+
+// DecodeObjectArrayParallel reads the next JSON array from its input and decodes each of its elements
+// to a new UnmarshalerObject returned by newElem, then passes it to collect with the index of the element in the array.
+//
+// Elements are split sequentially by the decoder, only their decoding is dispatched to a pool of workers goroutines,
+// each element being decoded by its own Decoder. newElem and the UnmarshalObject methods must be safe for concurrent use.
+// Calls to collect are serialized but not ordered, the index lets the caller place the results in order.
+// The Decoder of each element has the settings of dec, calls to its field hook are serialized,
+// and the errors of the fields it skips with SetContinueOnError are added to the FieldErrors of dec, in no particular order.
+//
+// null elements are skipped without calling newElem. It returns the first error encountered,
+// once an element fails to decode, no more element is dispatched.
+func (dec *Decoder) DecodeObjectArrayParallel(
+	workers int,
+	newElem func() UnmarshalerObject,
+	collect func(int, UnmarshalerObject),
+) error {
+	type job struct {
+		index int
+		start int
+		raw   []byte
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	var mux sync.Mutex
+	var decErr error
+	var wg sync.WaitGroup
+	jobs := make(chan job, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				index := j.index
+				elemDec := dec.elemDecoder(j.raw, j.start)
+				if hook := elemDec.fieldHook; hook != nil {
+					elemDec.fieldHook = func(key string, start, end int) {
+						mux.Lock()
+						hook(key, start, end)
+						mux.Unlock()
+					}
+				}
+				err := (&objectArray{newElem, func(elem UnmarshalerObject) {
+					mux.Lock()
+					collect(index, elem)
+					mux.Unlock()
+				}}).UnmarshalArray(elemDec)
+				mux.Lock()
+				dec.releaseElemDecoder(elemDec, j.start)
+				if err != nil && decErr == nil {
+					decErr = err
+				}
+				mux.Unlock()
+			}
+		}()
+	}
+	index := 0
+	err := dec.rawArray(func(start, end int) error {
+		mux.Lock()
+		err := decErr
+		mux.Unlock()
+		if err != nil {
+			return err
+		}
+		// the element is copied as workers unescape strings in place while the decoder keeps reading its buffer
+		jobs <- job{index, start, append([]byte(nil), dec.data[start:end]...)}
+		index++
+		return nil
+	})
+	close(jobs)
+	wg.Wait()
+	if decErr != nil {
+		return decErr
+	}
+	return err
+}
+
+// ArrayRawHashed reads the next JSON array from its input and, for each of its elements,
+// computes the checksum of its raw bytes with a new hash.Hash returned by h.
+// It then calls cb with the index of the element, its checksum and a Decoder positioned at the element,
+// which cb can use to decode it.
+//
+// The Decoder passed to cb only holds the element and is only valid during the call. It has the settings of dec
+// and the errors of the fields it skips with SetContinueOnError are added to the FieldErrors of dec.
+// If cb returns an error, no more element is read and the error is returned.
+func (dec *Decoder) ArrayRawHashed(h func() hash.Hash, cb func(index int, sum []byte, dec *Decoder) error) error {
+	index := 0
+	return dec.rawArray(func(start, end int) error {
+		raw := dec.data[start:end:end]
+		hasher := h()
+		if _, err := hasher.Write(raw); err != nil {
+			return err
+		}
+		sum := hasher.Sum(nil)
+		elemDec := dec.elemDecoder(raw, start)
+		err := cb(index, sum, elemDec)
+		dec.releaseElemDecoder(elemDec, start)
+		index++
+		return err
+	})
+}
+
+// elemDecoder returns a Decoder holding raw, the element found at start in the buffer of dec,
+// with the settings of dec. The offsets it passes to the field hook are relative to the buffer of dec.
+func (dec *Decoder) elemDecoder(raw []byte, start int) *Decoder {
+	elemDec := newDecoder(nil, 0)
+	elemDec.data = raw
+	elemDec.length = len(raw)
+	elemDec.maxValueBytes = dec.maxValueBytes
+	elemDec.maxExponent = dec.maxExponent
+	elemDec.migrator = dec.migrator
+	elemDec.keyTransform = dec.keyTransform
+	elemDec.continueOnError = dec.continueOnError
+	if hook := dec.fieldHook; hook != nil {
+		elemDec.fieldHook = func(key string, s, e int) {
+			hook(key, start+s, start+e)
+		}
+	}
+	return elemDec
+}
+
+// releaseElemDecoder adds the field errors of elemDec, a Decoder returned by elemDecoder, to those of dec,
+// then puts elemDec back in the pool. Its data is not kept as it belongs to dec or to a single element.
+func (dec *Decoder) releaseElemDecoder(elemDec *Decoder, start int) {
+	for _, fieldErr := range elemDec.fieldErrors {
+		fieldErr.Offset += start
+		dec.fieldErrors = append(dec.fieldErrors, fieldErr)
+	}
+	elemDec.data = nil
+	elemDec.addToPool()
+}
+
+// IndexArray reads the next JSON array from its input and returns the start offset of each of its elements.
+//
+// Elements are skipped, not decoded. Offsets are relative to the origin of the decoder's input buffer,
+// which is the start of the data given to the Decoder, or of everything read from its io.Reader.
+// If the JSON value is null, a nil slice is returned.
+func (dec *Decoder) IndexArray() ([]int, error) {
+	var offsets []int
+	err := dec.rawArray(func(start, end int) error {
+		offsets = append(offsets, start)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return offsets, nil
+}
+
+// rawArray reads the next JSON array from its input and calls f with
+// the start and end position in the buffer of each of its elements.
+func (dec *Decoder) rawArray(f func(start, end int) error) error {
+	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
+		switch dec.data[dec.cursor] {
+		case ' ', '\n', '\t', '\r', ',':
+			continue
+		case '[':
+			dec.cursor = dec.cursor + 1
+			for dec.nextChar() != 0 {
+				// closing array
+				if dec.data[dec.cursor] == ']' {
+					dec.cursor = dec.cursor + 1
+					return nil
+				}
+				start := dec.cursor
+				if err := dec.skipData(); err != nil {
+					return err
+				}
+				if dec.cursor <= start {
+					return InvalidJSONError("Invalid JSON while parsing array")
+				}
+				if err := f(start, dec.cursor); err != nil {
+					return err
+				}
+			}
+			return InvalidJSONError("Invalid JSON while parsing array")
+		case 'n':
+			// is null
+			dec.cursor = dec.cursor + 4
+			return nil
+		case '{', '"', 'f', 't', '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			err := InvalidTypeError(
+				fmt.Sprintf(
+					"Cannot unmarshall to array, wrong char '%s' found at pos %d",
+					string(dec.data[dec.cursor]),
+					dec.cursor,
+				),
+			)
+			dec.err = err
+			if skipErr := dec.skipData(); skipErr != nil {
+				return skipErr
+			}
+			return err
+		default:
+			return InvalidJSONError("Invalid JSON")
+		}
+	}
+	return InvalidJSONError("Invalid JSON")
+}
@@ -0,0 +1,290 @@
+package gojay
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoderArrayRawParallel(t *testing.T) {
+	json := []byte(`[{"test":1,"test2":"s"},{"test":2},3,"string",[1,2],null]`)
+	dec := newDecoder(nil, 0)
+	defer dec.addToPool()
+	dec.data = json
+	dec.length = len(json)
+	var mux sync.Mutex
+	raws := make(map[string]bool)
+	err := dec.ArrayRawParallel(3, func(raw []byte) error {
+		mux.Lock()
+		raws[string(raw)] = true
+		mux.Unlock()
+		return nil
+	})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(
+		t,
+		map[string]bool{
+			`{"test":1,"test2":"s"}`: true,
+			`{"test":2}`:             true,
+			`3`:                      true,
+			`"string"`:               true,
+			`[1,2]`:                  true,
+			`null`:                   true,
+		},
+		raws,
+		"raw elements should be equal to the expected ones",
+	)
+	assert.Equal(t, len(json), dec.cursor, "cursor should be at the end of the array")
+}
+
+func TestDecoderArrayRawParallelDecodeElements(t *testing.T) {
+	elems := make([]string, 0, 200)
+	for i := 0; i < 200; i++ {
+		elems = append(elems, fmt.Sprintf(`{"test":%d,"test3":"string"}`, i))
+	}
+	json := "[" + strings.Join(elems, ",") + "]"
+	// reading from a reader one byte at a time makes the decoder grow its buffer
+	dec := NewDecoder(iotest.OneByteReader(strings.NewReader(json)))
+	defer dec.addToPool()
+	var sum int64
+	err := dec.ArrayRawParallel(4, func(raw []byte) error {
+		v := &TestObj{}
+		if err := UnmarshalObject(raw, v); err != nil {
+			return err
+		}
+		atomic.AddInt64(&sum, int64(v.test))
+		return nil
+	})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, int64(199*200/2), sum, "sum should be equal to the sum of all elements")
+}
+
+func TestDecoderArrayRawParallelEscapedElements(t *testing.T) {
+	elems := make([]string, 0, 200)
+	for i := 0; i < 200; i++ {
+		elems = append(elems, fmt.Sprintf(`{"test3":"a\"b\"c","test":%d}`, i))
+	}
+	json := "[" + strings.Join(elems, ",") + "]"
+	dec := NewDecoder(iotest.OneByteReader(strings.NewReader(json)))
+	defer dec.addToPool()
+	var sum, bad int64
+	err := dec.ArrayRawParallel(4, func(raw []byte) error {
+		v := &TestObj{}
+		if err := UnmarshalObject(raw, v); err != nil {
+			return err
+		}
+		if v.test3 != `a"b"c` {
+			atomic.AddInt64(&bad, 1)
+		}
+		atomic.AddInt64(&sum, int64(v.test))
+		return nil
+	})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, int64(199*200/2), sum, "sum should be equal to the sum of all elements")
+	assert.Equal(t, int64(0), bad, "strings unescaped by workers should not corrupt other elements")
+}
+
+func TestDecoderArrayRawParallelCallbackError(t *testing.T) {
+	json := []byte(`[1,2,3,4,5,6,7,8,9]`)
+	dec := newDecoder(nil, 0)
+	defer dec.addToPool()
+	dec.data = json
+	dec.length = len(json)
+	testErr := errors.New("test error")
+	err := dec.ArrayRawParallel(2, func(raw []byte) error {
+		if string(raw) == "5" {
+			return testErr
+		}
+		return nil
+	})
+	assert.Equal(t, testErr, err, "err should be the one returned by the callback")
+}
+
+func TestDecoderArrayRawParallelErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		json    string
+		errType interface{}
+	}{
+		{
+			name:    "not-an-array",
+			json:    `{"test":1}`,
+			errType: InvalidTypeError(""),
+		},
+		{
+			name:    "unterminated-array",
+			json:    `[1,2`,
+			errType: InvalidJSONError(""),
+		},
+		{
+			name:    "unterminated-object",
+			json:    `[{"test":1`,
+			errType: InvalidJSONError(""),
+		},
+		{
+			name:    "invalid-json",
+			json:    `hello`,
+			errType: InvalidJSONError(""),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dec := newDecoder(nil, 0)
+			defer dec.addToPool()
+			dec.data = []byte(testCase.json)
+			dec.length = len(testCase.json)
+			err := dec.ArrayRawParallel(2, func(raw []byte) error {
+				return nil
+			})
+			assert.NotNil(t, err, "err should not be nil")
+			assert.IsType(t, testCase.errType, err, "err should be of the expected type")
+		})
+	}
+}
+
+func TestDecoderIndexArray(t *testing.T) {
+	json := `[{"test":1,"arr":[1,2]}, "string" ,3,null,  [true,false]]`
+	dec := NewDecoder(iotest.OneByteReader(strings.NewReader(json)))
+	defer dec.addToPool()
+	offsets, err := dec.IndexArray()
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []int{1, 25, 35, 37, 44}, offsets, "offsets should be the expected ones")
+	for i, prefix := range []string{`{"test":1`, `"string"`, `3`, `null`, `[true`} {
+		assert.True(t, strings.HasPrefix(json[offsets[i]:], prefix), "offset should point to the start of the element")
+	}
+	// seeking to an element and decoding it
+	v := &TestObj{}
+	err = UnmarshalObject([]byte(json[offsets[0]:]), v)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 1, v.test, "v.test should be 1")
+
+	dec = NewDecoder(strings.NewReader(`{"test":1}`))
+	defer dec.addToPool()
+	_, err = dec.IndexArray()
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+}
+
+func TestDecoderArrayRawHashed(t *testing.T) {
+	elems := []string{`{"test":1,"test3":"a\"b"}`, `{"test":2}`, `null`}
+	json := "[" + strings.Join(elems, ", ") + "]"
+	dec := NewDecoder(iotest.HalfReader(strings.NewReader(json)))
+	defer dec.addToPool()
+	var tests []int
+	var sums [][]byte
+	err := dec.ArrayRawHashed(sha256.New, func(index int, sum []byte, dec *Decoder) error {
+		assert.Equal(t, len(sums), index, "index should be the position of the element")
+		sums = append(sums, sum)
+		v := &TestObj{}
+		if _, err := dec.DecodeObject(v); err != nil {
+			return err
+		}
+		tests = append(tests, v.test)
+		return nil
+	})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []int{1, 2, 0}, tests, "elements should be decoded")
+	for i, elem := range elems {
+		expected := sha256.Sum256([]byte(elem))
+		assert.Equal(t, expected[:], sums[i], "sum should be the checksum of the raw element")
+	}
+
+	dec = NewDecoder(strings.NewReader(json))
+	defer dec.addToPool()
+	testErr := errors.New("checksum mismatch")
+	calls := 0
+	err = dec.ArrayRawHashed(sha256.New, func(index int, sum []byte, dec *Decoder) error {
+		calls++
+		return testErr
+	})
+	assert.Equal(t, testErr, err, "err should be the one returned by cb")
+	assert.Equal(t, 1, calls, "no more element should be read after an error")
+}
+
+func TestDecoderDecodeObjectArrayParallel(t *testing.T) {
+	elems := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		if i == 50 {
+			elems = append(elems, "null")
+			continue
+		}
+		elems = append(elems, fmt.Sprintf(`{"test":%d,"test3":"s\"%d"}`, i, i))
+	}
+	json := "[" + strings.Join(elems, ", ") + "]"
+	dec := NewDecoder(iotest.OneByteReader(strings.NewReader(json)))
+	defer dec.addToPool()
+	result := make([]*TestObj, len(elems))
+	err := dec.DecodeObjectArrayParallel(4, func() UnmarshalerObject {
+		return &TestObj{}
+	}, func(index int, elem UnmarshalerObject) {
+		result[index] = elem.(*TestObj)
+	})
+	assert.Nil(t, err, "err should be nil")
+	for i, v := range result {
+		if i == 50 {
+			assert.Nil(t, v, "null element should be skipped")
+			continue
+		}
+		assert.Equal(t, i, v.test, "element should be placed at its index")
+		assert.Equal(t, fmt.Sprintf(`s"%d`, i), v.test3, "element should be decoded")
+	}
+
+	dec = NewDecoder(strings.NewReader(`[{"test":1},"string",{"test":3}]`))
+	defer dec.addToPool()
+	err = dec.DecodeObjectArrayParallel(2, func() UnmarshalerObject {
+		return &TestObj{}
+	}, func(index int, elem UnmarshalerObject) {})
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+
+	dec = NewDecoder(strings.NewReader(`[{"test":1},{"test":2`))
+	defer dec.addToPool()
+	err = dec.DecodeObjectArrayParallel(2, func() UnmarshalerObject {
+		return &TestObj{}
+	}, func(index int, elem UnmarshalerObject) {})
+	assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+}
+
+func TestDecoderRawArrayElementSettings(t *testing.T) {
+	json := `[{"TEST":1,"test3":"abc"}, {"test":"x","test3":"d"}]`
+	newElem := func() UnmarshalerObject { return &TestObj{} }
+
+	dec := NewDecoder(strings.NewReader(json))
+	defer dec.addToPool()
+	dec.SetKeyTransformDecode(strings.ToLower)
+	dec.SetContinueOnError(true)
+	result := make([]*TestObj, 2)
+	err := dec.DecodeObjectArrayParallel(2, newElem, func(index int, elem UnmarshalerObject) {
+		result[index] = elem.(*TestObj)
+	})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 1, result[0].test, "keys should go through the key transform of the decoder")
+	assert.Equal(t, "d", result[1].test3, "the invalid field should be skipped")
+	assert.Len(t, dec.FieldErrors(), 1, "the field error should be added to the decoder")
+	assert.Equal(t, "test", dec.FieldErrors()[0].Key, "the field error should be the one of the invalid field")
+	assert.Equal(t, strings.Index(json, `"x"`), dec.FieldErrors()[0].Offset, "the offset should be relative to the decoder's buffer")
+
+	dec = NewDecoder(strings.NewReader(json))
+	defer dec.addToPool()
+	dec.SetMaxValueBytes(3)
+	err = dec.DecodeObjectArrayParallel(2, newElem, func(index int, elem UnmarshalerObject) {})
+	assert.IsType(t, LimitExceededError(""), err, "err should be of type LimitExceededError")
+
+	dec = NewDecoder(strings.NewReader(json))
+	defer dec.addToPool()
+	var spans []string
+	dec.SetFieldHook(func(key string, start, end int) {
+		spans = append(spans, key+"="+json[start:end])
+	})
+	err = dec.ArrayRawHashed(sha256.New, func(index int, sum []byte, dec *Decoder) error {
+		_, err := dec.DecodeObject(&TestObj{})
+		return err
+	})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []string{`TEST=1`, `test3="abc"`, `test="x"`, `test3="d"`}, spans, "spans should be relative to the decoder's buffer")
+}
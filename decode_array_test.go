@@ -1,7 +1,10 @@
 package gojay
 
 import (
+	"strconv"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -126,8 +129,8 @@ func TestDecodeSliceInvalidType(t *testing.T) {
 	result := testSliceObj{}
 	err := UnmarshalArray([]byte(`{}`), &result)
 	assert.NotNil(t, err, "err should not be nil")
-	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
-	assert.Equal(t, "Cannot unmarshall to array, wrong char '{' found at pos 0", err.Error(), "err should not be nil")
+	assert.IsType(t, &TypeMismatchError{}, err, "err should be of type *TypeMismatchError")
+	assert.Equal(t, "Cannot unmarshal, expected array got object at pos 0", err.Error(), "err should not be nil")
 }
 
 func TestDecoderChannelOfObjectsBasic(t *testing.T) {
@@ -169,3 +172,263 @@ func TestDecoderSliceInvalidJSON(t *testing.T) {
 	assert.NotNil(t, err, "Err must not be nil as JSON is invalid")
 	assert.IsType(t, InvalidJSONError(""), err, "err message must be 'Invalid JSON'")
 }
+
+func TestDecoderTuple(t *testing.T) {
+	testCases := []struct {
+		name         string
+		json         string
+		expectations func(t *testing.T, err error, id int, name string, ts int64)
+	}{
+		{
+			name: "basic",
+			json: `[1, "gojay", 1520000000]`,
+			expectations: func(t *testing.T, err error, id int, name string, ts int64) {
+				assert.Nil(t, err, "err must be nil")
+				assert.Equal(t, 1, id, "id must be equal to 1")
+				assert.Equal(t, "gojay", name, "name must be equal to 'gojay'")
+				assert.Equal(t, int64(1520000000), ts, "ts must be equal to 1520000000")
+			},
+		},
+		{
+			name: "null",
+			json: `null`,
+			expectations: func(t *testing.T, err error, id int, name string, ts int64) {
+				assert.Nil(t, err, "err must be nil")
+				assert.Equal(t, 0, id, "id must be equal to 0")
+			},
+		},
+		{
+			name: "too-few-elements",
+			json: `[1, "gojay"]`,
+			expectations: func(t *testing.T, err error, id int, name string, ts int64) {
+				assert.NotNil(t, err, "err must not be nil")
+				assert.IsType(t, InvalidTypeError(""), err, "err must be of type InvalidTypeError")
+				assert.Equal(t, "Cannot unmarshal to tuple, expected 3 elements got 2", err.Error(), "err message must be the expected one")
+			},
+		},
+		{
+			name: "too-many-elements",
+			json: `[1, "gojay", 1520000000, true]`,
+			expectations: func(t *testing.T, err error, id int, name string, ts int64) {
+				assert.NotNil(t, err, "err must not be nil")
+				assert.IsType(t, InvalidTypeError(""), err, "err must be of type InvalidTypeError")
+			},
+		},
+		{
+			name: "not-an-array",
+			json: `{"id":1}`,
+			expectations: func(t *testing.T, err error, id int, name string, ts int64) {
+				assert.NotNil(t, err, "err must not be nil")
+				assert.IsType(t, InvalidTypeError(""), err, "err must be of type InvalidTypeError")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var id int
+			var name string
+			var ts int64
+			dec := NewDecoder(strings.NewReader(testCase.json))
+			defer dec.addToPool()
+			err := dec.Tuple(
+				func(dec *Decoder) error {
+					return dec.AddInt(&id)
+				},
+				func(dec *Decoder) error {
+					return dec.AddString(&name)
+				},
+				func(dec *Decoder) error {
+					return dec.DecodeInt64(&ts)
+				},
+			)
+			testCase.expectations(t, err, id, name, ts)
+		})
+	}
+}
+
+func TestDecoderObjectArray(t *testing.T) {
+	testCases := []struct {
+		name         string
+		json         string
+		expectedTest []int
+		err          bool
+	}{
+		{
+			name:         "basic",
+			json:         `[{"test":1,"test3":"a"},{"test":2},null,{"test":3}]`,
+			expectedTest: []int{1, 2, 3},
+		},
+		{
+			name:         "empty",
+			json:         `[]`,
+			expectedTest: []int{},
+		},
+		{
+			name:         "null",
+			json:         `null`,
+			expectedTest: []int{},
+		},
+		{
+			name: "element-not-an-object",
+			json: `[{"test":1},"string"]`,
+			err:  true,
+		},
+		{
+			name: "not-an-array",
+			json: `{"test":1}`,
+			err:  true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(testCase.json))
+			defer dec.addToPool()
+			result := []*TestObj{}
+			err := dec.DecodeObjectArray(
+				func() UnmarshalerObject { return &TestObj{} },
+				func(v UnmarshalerObject) { result = append(result, v.(*TestObj)) },
+			)
+			if testCase.err {
+				assert.NotNil(t, err, "err should not be nil")
+				assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+				return
+			}
+			assert.Nil(t, err, "err should be nil")
+			tests := []int{}
+			for _, v := range result {
+				tests = append(tests, v.test)
+			}
+			assert.Equal(t, testCase.expectedTest, tests, "decoded elements should be the expected ones")
+		})
+	}
+}
+
+func TestDecoderArrayMaxValueBytes(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`["a","b","` + strings.Repeat("c", 100) + `"]`))
+	defer dec.addToPool()
+	dec.SetMaxValueBytes(50)
+	v := testSliceStrings{}
+	_, err := dec.DecodeArray(&v)
+	assert.NotNil(t, err, "err should not be nil")
+	assert.IsType(t, LimitExceededError(""), err, "err should be of type LimitExceededError")
+	assert.Equal(t, "Value starting at pos 9 exceeds the limit of 50 bytes", err.Error(), "err message should be the expected one")
+	assert.Equal(t, testSliceStrings{"a", "b", strings.Repeat("c", 100)}, v, "elements before the limit should be decoded")
+}
+
+func TestDecoderObjectArrayDedup(t *testing.T) {
+	json := `[{"test":1,"test3":"a"},{"test":2,"test3":"b"},{"test":1,"test3":"c"},{"test":3},{"test":2}]`
+	keyFn := func(v UnmarshalerObject) string {
+		return strconv.Itoa(v.(*TestObj).test)
+	}
+	newElem := func() UnmarshalerObject { return &TestObj{} }
+
+	dec := NewDecoder(strings.NewReader(json))
+	defer dec.addToPool()
+	result := []*TestObj{}
+	err := dec.DecodeObjectArrayDedup(keyFn, newElem, func(v UnmarshalerObject) {
+		result = append(result, v.(*TestObj))
+	})
+	assert.Nil(t, err, "err should be nil")
+	assert.Len(t, result, 3, "duplicates should be dropped")
+	assert.Equal(t, "a", result[0].test3, "first occurrence should be kept")
+	assert.Equal(t, "b", result[1].test3, "first occurrence should be kept")
+	assert.Equal(t, 3, result[2].test, "result[2].test should be 3")
+
+	// with a caller provided set
+	seen := make(map[string]struct{}, 8)
+	seen["3"] = struct{}{}
+	dec = NewDecoder(strings.NewReader(json))
+	defer dec.addToPool()
+	result = []*TestObj{}
+	err = dec.DecodeObjectArrayDedupWith(seen, keyFn, newElem, func(v UnmarshalerObject) {
+		result = append(result, v.(*TestObj))
+	})
+	assert.Nil(t, err, "err should be nil")
+	assert.Len(t, result, 2, "keys already in the set should be dropped")
+	assert.Len(t, seen, 3, "seen should hold all the keys")
+}
+
+type testClickEvent struct {
+	x, y int
+}
+
+func (e *testClickEvent) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "x":
+		return dec.AddInt(&e.x)
+	case "y":
+		return dec.AddInt(&e.y)
+	}
+	return nil
+}
+
+func (e *testClickEvent) NKeys() int {
+	return 2
+}
+
+type testKeyEvent struct {
+	key string
+}
+
+func (e *testKeyEvent) UnmarshalObject(dec *Decoder, key string) error {
+	if key == "key" {
+		return dec.AddString(&e.key)
+	}
+	return nil
+}
+
+func (e *testKeyEvent) NKeys() int {
+	return 1
+}
+
+func TestDecoderPolymorphicArray(t *testing.T) {
+	factory := func(typeName string) UnmarshalerObject {
+		switch typeName {
+		case "click":
+			return &testClickEvent{}
+		case "key":
+			return &testKeyEvent{}
+		}
+		return nil
+	}
+	json := `[{"x":1,"y":2,"type":"click"}, null, {"type":"key","key":"a"},{"type":"unknown","x":{"nested":1}},` +
+		`{"key":"no-type"},{"nested":{"type":"key"},"type":"click","x":3}]`
+	dec := NewDecoder(iotest.OneByteReader(strings.NewReader(json)))
+	defer dec.addToPool()
+	var events []UnmarshalerObject
+	err := dec.PolymorphicArray("type", factory, func(e UnmarshalerObject) {
+		events = append(events, e)
+	})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(
+		t,
+		[]UnmarshalerObject{&testClickEvent{1, 2}, &testKeyEvent{"a"}, &testClickEvent{3, 0}},
+		events,
+		"events should be decoded to their concrete type",
+	)
+
+	dec = NewDecoder(strings.NewReader(`[{"key\"":"x","key":"a\"b","type":"key"}]`))
+	defer dec.addToPool()
+	events = nil
+	err = dec.PolymorphicArray("type", factory, func(e UnmarshalerObject) {
+		events = append(events, e)
+	})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []UnmarshalerObject{&testKeyEvent{`a"b`}}, events, "escaped keys before the discriminator should be left as they were")
+
+	testCases := []struct {
+		name string
+		json string
+	}{
+		{name: "not-an-object", json: `[{"type":"key"},"string"]`},
+		{name: "discriminator-not-a-string", json: `[{"type":1}]`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(testCase.json))
+			defer dec.addToPool()
+			err := dec.PolymorphicArray("type", factory, func(e UnmarshalerObject) {})
+			assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+		})
+	}
+}
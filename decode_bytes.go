@@ -0,0 +1,63 @@
+package gojay
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// DecodeBytes reads the next JSON-encoded value from its input, a base64 string as encoded by AddBytes,
+// and stores the decoded bytes in the slice pointed to by v, reusing its capacity.
+//
+// The string is decoded straight from the decoder's buffer, with the standard base64 encoding.
+// If the JSON value is null, v is left untouched. If the string is not valid base64, an InvalidTypeError is returned.
+func (dec *Decoder) DecodeBytes(v *[]byte) error {
+	switch c := dec.nextChar(); c {
+	case 'n':
+		return dec.advance(4)
+	case '"':
+	case 0:
+		return InvalidJSONError("Invalid JSON while parsing bytes")
+	default:
+		err := InvalidTypeError(
+			fmt.Sprintf(
+				"Cannot unmarshall to []byte, wrong char '%s' found at pos %d",
+				string(c),
+				dec.cursor,
+			),
+		)
+		if skipErr := dec.skipData(); skipErr != nil {
+			return skipErr
+		}
+		return err
+	}
+	dec.cursor = dec.cursor + 1
+	start, end, err := dec.getString()
+	if err != nil {
+		return err
+	}
+	dec.cursor = end
+	// we do minus one to remove the last quote
+	src := dec.data[start : end-1]
+	n := base64.StdEncoding.DecodedLen(len(src))
+	b := (*v)[:0]
+	if cap(b) < n {
+		b = make([]byte, n)
+	}
+	n, err = base64.StdEncoding.Decode(b[:n], src)
+	if err != nil {
+		return InvalidTypeError(fmt.Sprintf("Cannot unmarshall to []byte, invalid base64: %s", err.Error()))
+	}
+	*v = b[:n]
+	return nil
+}
+
+// AddBytes decodes the next key, a base64 string, to a *[]byte.
+// If next key is neither a JSON string nor null, InvalidTypeError will be returned.
+func (dec *Decoder) AddBytes(v *[]byte) error {
+	err := dec.DecodeBytes(v)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
@@ -0,0 +1,61 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testBytesObj struct {
+	data  []byte
+	slash []byte
+	other []byte
+}
+
+func (o *testBytesObj) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "data":
+		return dec.AddBytes(&o.data)
+	case "slash":
+		return dec.AddBytes(&o.slash)
+	case "other":
+		return dec.AddBytes(&o.other)
+	}
+	return nil
+}
+
+func (o *testBytesObj) NKeys() int {
+	return 3
+}
+
+func TestDecoderBytes(t *testing.T) {
+	json := `{"data":"aGVsbG8gd29ybGQ=","slash":"//8=","other":null}`
+	v := &testBytesObj{other: []byte("keep")}
+	dec := NewDecoder(iotest.OneByteReader(strings.NewReader(json)))
+	defer dec.addToPool()
+	err := dec.Decode(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "hello world", string(v.data), "data should be decoded from base64")
+	assert.Equal(t, []byte{0xff, 0xff}, v.slash, "slash should be decoded from base64")
+	assert.Equal(t, "keep", string(v.other), "null should leave the value untouched")
+
+	b := make([]byte, 0, 16)
+	err = Unmarshal([]byte(`"AQID"`), &b)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, []byte{1, 2, 3}, b, "bytes should be decoded from base64")
+	assert.Equal(t, 16, cap(b), "capacity of the slice should be reused")
+}
+
+func TestDecoderBytesErrors(t *testing.T) {
+	var b []byte
+	err := Unmarshal([]byte(`"not base64!"`), &b)
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+
+	err = Unmarshal([]byte(`12`), &b)
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+
+	err = Unmarshal([]byte(`"AQID`), &b)
+	assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+}
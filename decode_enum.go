@@ -0,0 +1,170 @@
+package gojay
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// EnumMapping returns the mapping of the String() of each of values to its integer value,
+// to decode with DecodeEnum or DecodeStringerEnum the enums encoded with AddStringer and AddStringerKey.
+//
+// values must be of integer types, such as a type Status int implementing fmt.Stringer, EnumMapping panics otherwise.
+// The mapping is meant to be built once, for example in a package level variable, and shared by the decoders.
+func EnumMapping(values ...fmt.Stringer) map[string]int {
+	mapping := make(map[string]int, len(values))
+	for _, v := range values {
+		switch rv := reflect.ValueOf(v); rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			mapping[v.String()] = int(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			mapping[v.String()] = int(rv.Uint())
+		default:
+			panic(fmt.Sprintf("gojay: EnumMapping value of type %T is not an integer", v))
+		}
+	}
+	return mapping
+}
+
+// DecodeEnum reads the next JSON-encoded value from its input, a string, and stores in the int pointed to by value
+// the integer it maps to in mapping.
+//
+// If caseInsensitive is true, the string is looked up ignoring its case when it is not found as is,
+// by scanning mapping. If it then matches keys mapped to different integers, an InvalidTypeError is returned
+// as the result would depend on the iteration order of mapping.
+// If the string is not in mapping, an InvalidTypeError is returned. If the JSON value is null, value is left untouched.
+func (dec *Decoder) DecodeEnum(value *int, mapping map[string]int, caseInsensitive bool) error {
+	s, found, err := dec.decodeEnum(value, mapping, caseInsensitive)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return InvalidTypeError(fmt.Sprintf("Cannot unmarshall to enum, unknown value '%s'", s))
+	}
+	return nil
+}
+
+// DecodeEnumDefault is like DecodeEnum but stores def in the int pointed to by value
+// instead of returning an error when the string is not in mapping.
+func (dec *Decoder) DecodeEnumDefault(value *int, mapping map[string]int, caseInsensitive bool, def int) error {
+	_, found, err := dec.decodeEnum(value, mapping, caseInsensitive)
+	if err != nil {
+		return err
+	}
+	if !found {
+		*value = def
+	}
+	return nil
+}
+
+// DecodeStringerEnum is like DecodeEnum but stores the integer in value, a pointer to an integer type
+// such as a type Status int implementing fmt.Stringer, so that enums can be decoded to their own type.
+// mapping is usually built with EnumMapping. If value is not a non nil pointer to an integer type,
+// an InvalidUnmarshalError is returned.
+func (dec *Decoder) DecodeStringerEnum(value interface{}, mapping map[string]int, caseInsensitive bool) error {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return InvalidUnmarshalError(fmt.Sprintf(invalidUnmarshalErrorMsg, reflect.TypeOf(value)))
+	}
+	rv = rv.Elem()
+	var i int
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i = int(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i = int(rv.Uint())
+	default:
+		return InvalidUnmarshalError(fmt.Sprintf(invalidUnmarshalErrorMsg, reflect.TypeOf(value)))
+	}
+	if err := dec.DecodeEnum(&i, mapping, caseInsensitive); err != nil {
+		return err
+	}
+	if rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uintptr {
+		rv.SetUint(uint64(i))
+	} else {
+		rv.SetInt(int64(i))
+	}
+	return nil
+}
+
+// AddStringerEnum decodes the next key, a string, to the integer it maps to in mapping, stored in value
+// as DecodeStringerEnum does.
+// If next key is neither a JSON string found in mapping nor null, InvalidTypeError will be returned.
+func (dec *Decoder) AddStringerEnum(value interface{}, mapping map[string]int, caseInsensitive bool) error {
+	err := dec.DecodeStringerEnum(value, mapping, caseInsensitive)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+// AddEnum decodes the next key, a string, to the *int it maps to in mapping.
+// If next key is neither a JSON string found in mapping nor null, InvalidTypeError will be returned.
+func (dec *Decoder) AddEnum(value *int, mapping map[string]int, caseInsensitive bool) error {
+	err := dec.DecodeEnum(value, mapping, caseInsensitive)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+// AddEnumDefault decodes the next key, a string, to the *int it maps to in mapping, or to def if it is not in mapping.
+// If next key is neither a JSON string nor null, InvalidTypeError will be returned.
+func (dec *Decoder) AddEnumDefault(value *int, mapping map[string]int, caseInsensitive bool, def int) error {
+	err := dec.DecodeEnumDefault(value, mapping, caseInsensitive, def)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+// decodeEnum decodes the next string and looks it up in mapping,
+// it returns the string and reports whether it was found. null is reported as found.
+func (dec *Decoder) decodeEnum(value *int, mapping map[string]int, caseInsensitive bool) (string, bool, error) {
+	switch c := dec.nextChar(); c {
+	case 'n':
+		return "", true, dec.assertNull()
+	case '"':
+	case 0:
+		return "", false, InvalidJSONError("Invalid JSON while parsing enum")
+	default:
+		return "", false, InvalidTypeError(
+			fmt.Sprintf(
+				"Cannot unmarshall to enum, wrong char '%s' found at pos %d",
+				string(c),
+				dec.cursor,
+			),
+		)
+	}
+	var s string
+	if err := dec.DecodeString(&s); err != nil {
+		return "", false, err
+	}
+	if v, ok := mapping[s]; ok {
+		*value = v
+		return s, true, nil
+	}
+	if caseInsensitive {
+		var match string
+		var matched int
+		for k, v := range mapping {
+			if !strings.EqualFold(k, s) {
+				continue
+			}
+			if match != "" && v != matched {
+				return "", false, InvalidTypeError(
+					fmt.Sprintf("Cannot unmarshall to enum, '%s' matches both '%s' and '%s' ignoring case", s, match, k),
+				)
+			}
+			match, matched = k, v
+		}
+		if match != "" {
+			*value = matched
+			return s, true, nil
+		}
+	}
+	return s, false, nil
+}
@@ -0,0 +1,210 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testStatusUnknown = iota
+	testStatusActive
+	testStatusInactive
+)
+
+var testStatusMapping = map[string]int{
+	"active":   testStatusActive,
+	"INACTIVE": testStatusInactive,
+}
+
+type testDecodeEnum struct {
+	status int
+	other  int
+}
+
+func (t *testDecodeEnum) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "status":
+		return dec.AddEnum(&t.status, testStatusMapping, true)
+	case "other":
+		return dec.AddEnumDefault(&t.other, testStatusMapping, false, testStatusUnknown)
+	}
+	return nil
+}
+
+func (t *testDecodeEnum) NKeys() int {
+	return 2
+}
+
+func TestDecoderEnum(t *testing.T) {
+	testCases := []struct {
+		name           string
+		json           string
+		expectedStatus int
+		expectedOther  int
+		err            bool
+	}{
+		{
+			name:           "exact-case",
+			json:           `{"status":"active","other":"INACTIVE"}`,
+			expectedStatus: testStatusActive,
+			expectedOther:  testStatusInactive,
+		},
+		{
+			name:           "folded-case",
+			json:           `{"status":"ACTIVE","other":"active"}`,
+			expectedStatus: testStatusActive,
+			expectedOther:  testStatusActive,
+		},
+		{
+			name:           "folded-case-mapping-upper",
+			json:           `{"status":"Inactive"}`,
+			expectedStatus: testStatusInactive,
+		},
+		{
+			name:           "unknown-with-default",
+			json:           `{"status":"active","other":"Active"}`,
+			expectedStatus: testStatusActive,
+			expectedOther:  testStatusUnknown,
+		},
+		{
+			name:           "null",
+			json:           `{"status":null}`,
+			expectedStatus: testStatusUnknown,
+		},
+		{
+			name: "unknown",
+			json: `{"status":"deleted"}`,
+			err:  true,
+		},
+		{
+			name: "not-a-string",
+			json: `{"status":1}`,
+			err:  true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			v := &testDecodeEnum{}
+			err := UnmarshalObject([]byte(testCase.json), v)
+			if testCase.err {
+				assert.NotNil(t, err, "err should not be nil")
+				assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+				return
+			}
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expectedStatus, v.status, "v.status should be the expected one")
+			assert.Equal(t, testCase.expectedOther, v.other, "v.other should be the expected one")
+		})
+	}
+	var v int
+	err := UnmarshalObject([]byte(`{"status":"deleted"}`), &testDecodeEnum{})
+	assert.Equal(t, "Cannot unmarshall to enum, unknown value 'deleted'", err.Error(), "err message should contain the value")
+	dec := NewDecoder(nil)
+	defer dec.addToPool()
+	dec.data = []byte(`"Active"`)
+	dec.length = len(dec.data)
+	err = dec.DecodeEnum(&v, testStatusMapping, true)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, testStatusActive, v, "v should be the expected one")
+
+	err = UnmarshalObject([]byte(`{"status":nope}`), &testDecodeEnum{})
+	assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+}
+
+func TestDecoderEnumAmbiguousCase(t *testing.T) {
+	mapping := map[string]int{"on": 1, "ON": 2, "Off": 3, "OFF": 3}
+	for i := 0; i < 10; i++ {
+		dec := NewDecoder(nil)
+		dec.data = []byte(`"On"`)
+		dec.length = len(dec.data)
+		v := 0
+		err := dec.DecodeEnum(&v, mapping, true)
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+		assert.Equal(t, 0, v, "v should be left untouched")
+		dec.addToPool()
+
+		dec = NewDecoder(nil)
+		dec.data = []byte(`"off"`)
+		dec.length = len(dec.data)
+		err = dec.DecodeEnum(&v, mapping, true)
+		assert.Nil(t, err, "keys mapped to the same integer should not be ambiguous")
+		assert.Equal(t, 3, v, "v should be the expected one")
+		dec.addToPool()
+	}
+}
+
+type testColor uint8
+
+const (
+	testColorRed testColor = iota + 1
+	testColorGreen
+)
+
+func (c testColor) String() string {
+	switch c {
+	case testColorRed:
+		return "red"
+	case testColorGreen:
+		return "green"
+	}
+	return "unknown"
+}
+
+var testColorMapping = EnumMapping(testColorRed, testColorGreen)
+
+type testColorObj struct {
+	color testColor
+	other testColor
+}
+
+func (t *testColorObj) MarshalObject(enc *Encoder) {
+	enc.AddStringerKey("color", t.color)
+	enc.AddStringerKey("other", t.other)
+}
+
+func (t *testColorObj) IsNil() bool {
+	return t == nil
+}
+
+func (t *testColorObj) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "color":
+		return dec.AddStringerEnum(&t.color, testColorMapping, false)
+	case "other":
+		return dec.AddStringerEnum(&t.other, testColorMapping, true)
+	}
+	return nil
+}
+
+func (t *testColorObj) NKeys() int {
+	return 2
+}
+
+func TestDecoderStringerEnum(t *testing.T) {
+	assert.Equal(t, map[string]int{"red": 1, "green": 2}, testColorMapping, "mapping should map String() to the values")
+
+	b, err := MarshalObject(&testColorObj{testColorGreen, testColorRed})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, `{"color":"green","other":"red"}`, string(b), "Result of marshalling is different as the one expected")
+	v := &testColorObj{}
+	err = UnmarshalObject(b, v)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, testColorObj{testColorGreen, testColorRed}, *v, "v should be decoded back")
+
+	v = &testColorObj{other: testColorGreen}
+	err = UnmarshalObject([]byte(`{"color":"RED","other":null}`), v)
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	err = UnmarshalObject([]byte(`{"other":"RED"}`), v)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, testColorRed, v.other, "v.other should be decoded case insensitively")
+
+	var s string
+	dec := NewDecoder(nil)
+	defer dec.addToPool()
+	dec.data = []byte(`"red"`)
+	dec.length = len(dec.data)
+	err = dec.DecodeStringerEnum(&s, testColorMapping, false)
+	assert.IsType(t, InvalidUnmarshalError(""), err, "err should be of type InvalidUnmarshalError")
+	assert.Panics(t, func() { EnumMapping(&testStringerPtr{"a"}) }, "EnumMapping should panic on non integer values")
+}
@@ -0,0 +1,91 @@
+package gojay
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// DecodeRequest decodes the JSON object in the body of r to v.
+// It is DecodeRequestLimit without a limit on the size of the body.
+func DecodeRequest(r *http.Request, v UnmarshalerObject) error {
+	return DecodeRequestLimit(r, v, 0)
+}
+
+// DecodeRequestLimit decodes the JSON object in the body of r to v, reading at most max bytes of the body,
+// 0 meaning no limit.
+//
+// If the request has a Content-Type header, it must be application/json or end with +json,
+// otherwise an InvalidTypeError is returned and the body is not read.
+// If the Content-Length of the request exceeds max, or if the body turns out to be longer than max
+// before the object ends, a LimitExceededError is returned.
+//
+// The body is read straight from r.Body through the Decoder's buffer, never read whole to a []byte first.
+// When the Content-Length is known and within max, the buffer is sized to it.
+func DecodeRequestLimit(r *http.Request, v UnmarshalerObject, max int64) error {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mt != "application/json" && !strings.HasSuffix(mt, "+json")) {
+			return InvalidTypeError(fmt.Sprintf("Cannot decode request, Content-Type %q is not JSON", ct))
+		}
+	}
+	if max > 0 && r.ContentLength > max {
+		return requestLimitError(max)
+	}
+	body := r.Body
+	if body == nil {
+		body = http.NoBody
+	}
+	bufSize := poolConfig.DecoderBufferSize
+	if max > 0 && r.ContentLength > 0 {
+		bufSize = int(r.ContentLength)
+	}
+	var reader io.Reader = body
+	var lr *limitReader
+	if max > 0 {
+		lr = &limitReader{r: body, n: max}
+		reader = lr
+	}
+	dec := newDecoder(reader, bufSize)
+	defer dec.addToPool()
+	err := dec.expectKind(KindObject)
+	if err == nil {
+		_, err = dec.DecodeObject(v)
+	}
+	if err == nil {
+		err = dec.err
+	}
+	if lr != nil && lr.exceeded {
+		return requestLimitError(max)
+	}
+	return err
+}
+
+func requestLimitError(max int64) error {
+	return LimitExceededError(fmt.Sprintf("Request body exceeds the limit of %d bytes", max))
+}
+
+// limitReader reads at most n bytes from r, and records whether r holds more
+type limitReader struct {
+	r        io.Reader
+	n        int64
+	exceeded bool
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		var probe [1]byte
+		if n, _ := l.r.Read(probe[:]); n > 0 {
+			l.exceeded = true
+		}
+		return 0, io.EOF
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
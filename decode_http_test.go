@@ -0,0 +1,44 @@
+package gojay
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeRequest(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"x":1,"y":2}`))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	v := &testClickEvent{}
+	err := DecodeRequest(r, v)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, testClickEvent{1, 2}, *v, "v should be decoded")
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"x":3}`))
+	v = &testClickEvent{}
+	err = DecodeRequestLimit(r, v, 7)
+	assert.Nil(t, err, "err should be nil when the body fits the limit")
+	assert.Equal(t, 3, v.x, "v.x should be decoded")
+}
+
+func TestDecodeRequestErrors(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"x":1}`))
+	r.Header.Set("Content-Type", "text/plain")
+	err := DecodeRequest(r, &testClickEvent{})
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"x":1,"y":2}`))
+	err = DecodeRequestLimit(r, &testClickEvent{}, 8)
+	assert.IsType(t, LimitExceededError(""), err, "err should be of type LimitExceededError when Content-Length exceeds the limit")
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"x":1,"y":2}`))
+	r.ContentLength = -1
+	err = DecodeRequestLimit(r, &testClickEvent{}, 8)
+	assert.IsType(t, LimitExceededError(""), err, "err should be of type LimitExceededError when the body exceeds the limit")
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`[1]`))
+	err = DecodeRequest(r, &testClickEvent{})
+	assert.IsType(t, &TypeMismatchError{}, err, "err should be of type *TypeMismatchError")
+}
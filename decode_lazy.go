@@ -0,0 +1,39 @@
+package gojay
+
+// LazyDecodeFunc decodes a value captured by DecodeLazyField into v, see Unmarshal for the types v can take.
+type LazyDecodeFunc func(v interface{}) error
+
+// DecodeLazyField reads the value of the object key key without decoding it and returns a LazyDecodeFunc
+// decoding it on demand, so that the cost of decoding a field which may not be used is only paid if it is.
+//
+// The raw bytes of the value are copied and held by the LazyDecodeFunc, which can be called any number of times,
+// after the Decoder has been released. Errors returned by the LazyDecodeFunc are of type *FieldError.
+func (dec *Decoder) DecodeLazyField(key string) (LazyDecodeFunc, error) {
+	if dec.nextChar() == 0 {
+		return nil, InvalidJSONError("Invalid JSON while capturing lazy field")
+	}
+	start := dec.cursor
+	if err := dec.skipData(); err != nil {
+		return nil, err
+	}
+	raw := make([]byte, dec.cursor-start)
+	copy(raw, dec.data[start:dec.cursor])
+	key = string([]byte(key))
+	return func(v interface{}) error {
+		if err := Unmarshal(raw, v); err != nil {
+			return &FieldError{Key: key, Offset: start, Err: err}
+		}
+		return nil
+	}, nil
+}
+
+// AddLazyField captures the value of the next key to a *LazyDecodeFunc, see DecodeLazyField.
+func (dec *Decoder) AddLazyField(key string, f *LazyDecodeFunc) error {
+	lazy, err := dec.DecodeLazyField(key)
+	if err != nil {
+		return err
+	}
+	*f = lazy
+	dec.called |= 1
+	return nil
+}
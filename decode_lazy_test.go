@@ -0,0 +1,60 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLazyObj struct {
+	id      int
+	details LazyDecodeFunc
+	tags    LazyDecodeFunc
+}
+
+func (t *testLazyObj) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "id":
+		return dec.AddInt(&t.id)
+	case "details", "tags":
+		lazy := &t.details
+		if key == "tags" {
+			lazy = &t.tags
+		}
+		return dec.AddLazyField(key, lazy)
+	}
+	return nil
+}
+
+func (t *testLazyObj) NKeys() int {
+	return 3
+}
+
+func TestDecoderLazyField(t *testing.T) {
+	json := `{"details": {"test":1,"test3":"deep \"value\""},"tags":"not-an-array","id":42}`
+	dec := NewDecoder(iotest.OneByteReader(strings.NewReader(json)))
+	v := &testLazyObj{}
+	err := dec.Decode(v)
+	assert.Nil(t, err, "err should be nil")
+	dec.addToPool()
+	assert.Equal(t, 42, v.id, "v.id should be decoded")
+
+	details := &TestObj{}
+	err = v.details(details)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 1, details.test, "details.test should be decoded on demand")
+	assert.Equal(t, `deep "value"`, details.test3, "details.test3 should be decoded on demand")
+
+	err = v.tags(&testSliceStrings{})
+	assert.NotNil(t, err, "err should not be nil")
+	assert.IsType(t, &FieldError{}, err, "err should be of type *FieldError")
+	assert.Equal(t, "tags", err.(*FieldError).Key, "key should be the one of the lazy field")
+	assert.Equal(t, 55, err.(*FieldError).Offset, "offset should be the start of the lazy field value")
+
+	dec = NewDecoder(strings.NewReader(`{"details": {"test":1`))
+	defer dec.addToPool()
+	err = dec.Decode(&testLazyObj{})
+	assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+}
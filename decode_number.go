@@ -2,6 +2,9 @@ package gojay
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
 )
 
 var digits []int8
@@ -20,6 +23,10 @@ const maxInt32Length = 10
 const maxInt64Length = 19
 const invalidNumber = int8(-1)
 
+// defaultMaxExponent is the default maximum absolute value of the exponent of a decoded float,
+// it is above the range of float64 while keeping adversarial exponents cheap to reject.
+const defaultMaxExponent = 400
+
 var pow10uint64 = [20]uint64{
 	0,
 	1,
@@ -319,6 +326,37 @@ func (dec *Decoder) DecodeFloat64(v *float64) error {
 	return InvalidJSONError("Invalid JSON while parsing float")
 }
 
+// DecodeFloatWithUnit reads the next JSON-encoded value from its input, a string made of a number followed by unit
+// such as "12.5ms", and stores the number in the float64 pointed to by v.
+//
+// If the string does not end with unit or its remaining part is not a number, an InvalidTypeError is returned.
+func (dec *Decoder) DecodeFloatWithUnit(v *float64, unit string) error {
+	var s string
+	if dec.nextChar() != '"' {
+		// let DecodeString handle null and invalid types
+		return dec.DecodeString(&s)
+	}
+	if err := dec.DecodeString(&s); err != nil {
+		return err
+	}
+	if !strings.HasSuffix(s, unit) {
+		return InvalidTypeError(fmt.Sprintf("Cannot unmarshall to float, unit '%s' not found in '%s'", unit, s))
+	}
+	f, err := strconv.ParseFloat(s[:len(s)-len(unit)], 64)
+	if err != nil {
+		return InvalidTypeError(fmt.Sprintf("Cannot unmarshall to float, invalid number in '%s'", s))
+	}
+	*v = f
+	return nil
+}
+
+// SetMaxExponent sets the maximum absolute value of the exponent of the floats decoded,
+// 0 meaning the default value of 400. A number with a bigger exponent makes decoding fail
+// with a LimitExceededError before the number is computed.
+func (dec *Decoder) SetMaxExponent(n int) {
+	dec.maxExponent = n
+}
+
 func (dec *Decoder) skipNumber() (int, error) {
 	end := dec.cursor + 1
 	// look for following numbers
@@ -327,7 +365,7 @@ func (dec *Decoder) skipNumber() (int, error) {
 		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			end = j + 1
 			continue
-		case '.':
+		case '.', 'e', 'E', '+', '-':
 			end = j + 1
 			continue
 		case ',', '}', ']':
@@ -428,11 +466,16 @@ func (dec *Decoder) getUint32(b byte) (uint32, error) {
 func (dec *Decoder) getFloat(b byte) (float64, error) {
 	var end = dec.cursor
 	var start = dec.cursor
+	var numStart = dec.cursor
 	// look for following numbers
 	for j := dec.cursor + 1; j < dec.length || dec.read(); j++ {
 		switch dec.data[j] {
 		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			end = j
+			if end-start >= maxInt64Length-1 {
+				// too many digits to be computed as an int64
+				return dec.getFloatSlow(numStart)
+			}
 			continue
 		case '.':
 			// we get part before decimal as integer
@@ -445,9 +488,16 @@ func (dec *Decoder) getFloat(b byte) (float64, error) {
 				c := dec.data[i]
 				if isDigit(c) {
 					end = i
+					if end-numStart >= maxInt64Length-1 {
+						// too many digits to be computed as an int64
+						return dec.getFloatSlow(numStart)
+					}
 					beforeDecimal = (beforeDecimal << 3) + (beforeDecimal << 1)
 					continue
 				}
+				if c == 'e' || c == 'E' {
+					return dec.getFloatExponent(numStart, i)
+				}
 				dec.cursor = i
 				break
 			}
@@ -456,6 +506,8 @@ func (dec *Decoder) getFloat(b byte) (float64, error) {
 			afterDecimal := dec.atoi64(start, end)
 			pow := pow10uint64[end-start+2]
 			return float64(beforeDecimal+afterDecimal) / float64(pow), nil
+		case 'e', 'E':
+			return dec.getFloatExponent(numStart, j)
 		case ' ', '\n', '\t', '\r':
 			continue
 		case ',', '}', ']': // does not have decimal
@@ -468,6 +520,73 @@ func (dec *Decoder) getFloat(b byte) (float64, error) {
 	return float64(dec.atoi64(start, end)), nil
 }
 
+// getFloatExponent parses the number starting at start whose exponent starts at e.
+// The exponent is checked against the decoder's max exponent before calling strconv,
+// so that adversarial exponents are rejected without being computed.
+func (dec *Decoder) getFloatExponent(start, e int) (float64, error) {
+	maxExponent := dec.maxExponent
+	if maxExponent == 0 {
+		maxExponent = defaultMaxExponent
+	}
+	j := e + 1
+	if (j < dec.length || dec.read()) && (dec.data[j] == '-' || dec.data[j] == '+') {
+		j++
+	}
+	expStart := j
+	exp := 0
+	for ; j < dec.length || dec.read(); j++ {
+		c := dec.data[j]
+		if !isDigit(c) {
+			break
+		}
+		exp = exp*10 + int(c-'0')
+		if exp > maxExponent {
+			return 0, LimitExceededError(
+				fmt.Sprintf(
+					"Exponent of number at pos %d exceeds the limit of %d",
+					start,
+					maxExponent,
+				),
+			)
+		}
+	}
+	if j == expStart {
+		return 0, InvalidJSONError("Invalid JSON while parsing number")
+	}
+	return dec.parseFloat(start, j)
+}
+
+// getFloatSlow parses the number starting at start using strconv,
+// it is used for numbers having too many digits to be computed as an int64.
+func (dec *Decoder) getFloatSlow(start int) (float64, error) {
+	j := start
+	for ; j < dec.length || dec.read(); j++ {
+		c := dec.data[j]
+		if isDigit(c) || c == '.' {
+			continue
+		}
+		if c == 'e' || c == 'E' {
+			return dec.getFloatExponent(start, j)
+		}
+		break
+	}
+	return dec.parseFloat(start, j)
+}
+
+// parseFloat parses the number between start and end using strconv and moves the cursor to end.
+func (dec *Decoder) parseFloat(start, end int) (float64, error) {
+	d := dec.data[start:end]
+	f, err := strconv.ParseFloat(*(*string)(unsafe.Pointer(&d)), 64)
+	if err != nil {
+		if err.(*strconv.NumError).Err == strconv.ErrRange {
+			return 0, InvalidTypeError(fmt.Sprintf("Cannot unmarshall to float, number at pos %d overflows float64", start))
+		}
+		return 0, InvalidJSONError("Invalid JSON while parsing number")
+	}
+	dec.cursor = end
+	return f, nil
+}
+
 func (dec *Decoder) atoi64(start, end int) int64 {
 	var ll = end + 1 - start
 	var val = int64(digits[dec.data[start]])
@@ -1,6 +1,7 @@
 package gojay
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -308,3 +309,119 @@ func TestDecoderFloatInvalidJSON(t *testing.T) {
 	assert.NotNil(t, err, "Err must not be nil as JSON is invalid")
 	assert.IsType(t, InvalidJSONError(""), err, "err message must be 'Invalid JSON'")
 }
+
+func TestDecoderFloatExponent(t *testing.T) {
+	testCases := []struct {
+		name        string
+		json        string
+		maxExponent int
+		expected    float64
+		errType     interface{}
+	}{
+		{name: "exponent", json: `1e5`, expected: 1e5},
+		{name: "exponent-upper-case", json: `2E3`, expected: 2e3},
+		{name: "negative-exponent", json: `-2.5e-3`, expected: -2.5e-3},
+		{name: "positive-exponent", json: `1.5e+2`, expected: 1.5e2},
+		{name: "exponent-in-array", json: `[1e2]`, expected: 1e2},
+		{name: "many-digits", json: `0.12345678901234567890123`, expected: 0.12345678901234567890123},
+		{name: "many-integer-digits", json: `123456789012345678901234567890`, expected: 123456789012345678901234567890},
+		// input found by fuzzing, the exponent must be rejected without being computed
+		{name: "fuzz-huge-exponent", json: `1e9999999999`, errType: LimitExceededError("")},
+		{name: "fuzz-huge-negative-exponent", json: `1e-99999999999999999999`, errType: LimitExceededError("")},
+		{name: "within-custom-limit", json: `1e10`, maxExponent: 10, expected: 1e10},
+		{name: "above-custom-limit", json: `1e11`, maxExponent: 10, errType: LimitExceededError("")},
+		{name: "overflow", json: `1e350`, errType: InvalidTypeError("")},
+		{name: "missing-exponent", json: `1e`, errType: InvalidJSONError("")},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(testCase.json))
+			defer dec.addToPool()
+			dec.SetMaxExponent(testCase.maxExponent)
+			var v float64
+			var err error
+			if testCase.json[0] == '[' {
+				err = dec.Tuple(func(dec *Decoder) error { return dec.AddFloat(&v) })
+			} else {
+				err = dec.DecodeFloat64(&v)
+			}
+			if testCase.errType != nil {
+				assert.NotNil(t, err, "err must not be nil")
+				assert.IsType(t, testCase.errType, err, "err must be of the expected type")
+				return
+			}
+			assert.Nil(t, err, "err must be nil")
+			assert.Equal(t, testCase.expected, v, "v must be equal to the expected value")
+		})
+	}
+}
+
+func TestDecoderSkipNumberExponent(t *testing.T) {
+	v := &testDecodeFloatWithUnit{}
+	err := UnmarshalObject([]byte(`{"other":1.5e-3,"latency":"12.5ms"}`), v)
+	assert.Nil(t, err, "Err must be nil")
+	assert.Equal(t, 12.5, v.latency, "v.latency must be equal to 12.5")
+}
+
+type testDecodeFloatWithUnit struct {
+	latency float64
+	size    float64
+}
+
+func (t *testDecodeFloatWithUnit) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "latency":
+		return dec.AddFloatWithUnit(&t.latency, "ms")
+	case "size":
+		return dec.AddFloatWithUnit(&t.size, "GB")
+	}
+	return nil
+}
+
+func (t *testDecodeFloatWithUnit) NKeys() int {
+	return 2
+}
+
+func TestDecoderFloatWithUnit(t *testing.T) {
+	v := &testDecodeFloatWithUnit{}
+	err := UnmarshalObject([]byte(`{"latency":"12.5ms","size":"3.2GB"}`), v)
+	assert.Nil(t, err, "Err must be nil")
+	assert.Equal(t, 12.5, v.latency, "v.latency must be equal to 12.5")
+	assert.Equal(t, 3.2, v.size, "v.size must be equal to 3.2")
+}
+
+func TestDecoderFloatWithUnitNull(t *testing.T) {
+	v := &testDecodeFloatWithUnit{}
+	err := UnmarshalObject([]byte(`{"latency":null,"size":"3.2GB"}`), v)
+	assert.Nil(t, err, "Err must be nil")
+	assert.Equal(t, 0.0, v.latency, "v.latency must be equal to 0")
+	assert.Equal(t, 3.2, v.size, "v.size must be equal to 3.2")
+}
+
+func TestDecoderFloatWithUnitErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		json string
+	}{
+		{
+			name: "wrong-unit",
+			json: `{"latency":"12.5s"}`,
+		},
+		{
+			name: "not-a-number",
+			json: `{"latency":"abcms"}`,
+		},
+		{
+			name: "not-a-string",
+			json: `{"latency":12.5}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			v := &testDecodeFloatWithUnit{}
+			err := UnmarshalObject([]byte(testCase.json), v)
+			assert.NotNil(t, err, "Err must not be nil")
+			assert.IsType(t, InvalidTypeError(""), err, "err must be of type InvalidTypeError")
+		})
+	}
+}
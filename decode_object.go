@@ -2,6 +2,7 @@ package gojay
 
 import (
 	"fmt"
+	"strings"
 	"unsafe"
 )
 
@@ -11,31 +12,112 @@ import (
 //
 // See the documentation for Unmarshal for details about the conversion of JSON into a Go value.
 func (dec *Decoder) DecodeObject(j UnmarshalerObject) (int, error) {
+	return dec.decodeObject(j, nil)
+}
+
+// DecodeObjectCapture reads the next JSON object from its input and decodes it to j as DecodeObject does,
+// but captures the raw key and value of every key not consumed by j, in their original order.
+//
+// Captured fields are returned comma separated, for example "a":1,"b":[true], so that they can be written back
+// verbatim inside an object with AddEmbeddedJSON. If no field was captured, a nil EmbeddedJSON is returned.
+// Capturing reads the whole object, keys are not skipped once j.NKeys() keys are decoded.
+func (dec *Decoder) DecodeObjectCapture(j UnmarshalerObject) (EmbeddedJSON, error) {
+	var captured []byte
+	if _, err := dec.decodeObject(j, &captured); err != nil {
+		return nil, err
+	}
+	if len(captured) == 0 {
+		return nil, nil
+	}
+	return captured, nil
+}
+
+// decodeObject decodes the next JSON object to j,
+// appending the fields not consumed by j to captured if it is not nil.
+func (dec *Decoder) decodeObject(j UnmarshalerObject, captured *[]byte) (int, error) {
 	keys := j.NKeys()
 	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
 		switch dec.data[dec.cursor] {
 		case ' ', '\n', '\t', '\r', ',':
 		case '{':
 			dec.cursor = dec.cursor + 1
-			for (dec.cursor < dec.length || dec.read()) && dec.keysDone < keys {
+			for (dec.cursor < dec.length || dec.read()) && (dec.keysDone < keys || captured != nil) {
+				// the raw key is appended to captured before nextKey unescapes it in place,
+				// it is removed if the field is decoded
+				var mark, valueStart int
+				if captured != nil {
+					mark = len(*captured)
+					if dec.nextChar() == '"' {
+						keyStart := dec.cursor
+						dec.cursor = dec.cursor + 1
+						if err := dec.skipString(); err != nil {
+							return 0, err
+						}
+						if mark > 0 {
+							*captured = append(*captured, ',')
+						}
+						*captured = append(*captured, dec.data[keyStart:dec.cursor]...)
+						dec.cursor = keyStart
+					}
+				}
 				k, done, err := dec.nextKey()
 				if err != nil {
 					return 0, err
 				} else if done {
 					return dec.cursor, nil
 				}
-				err = j.UnmarshalObject(dec, k)
-				if err != nil {
-					return 0, err
-				} else if dec.called&1 == 0 {
-					err := dec.skipData()
+				valueStart = dec.cursor
+				var start int
+				if dec.fieldHook != nil || dec.maxValueBytes > 0 || dec.continueOnError {
+					dec.nextChar()
+					start = dec.cursor
+				}
+				if dec.keyTransform != nil {
+					k = dec.keyTransform(k)
+				}
+				var handled bool
+				if dec.migrator != nil {
+					k, handled, err = dec.migrator(k, dec)
 					if err != nil {
 						return 0, err
 					}
-				} else {
-					dec.keysDone++
+				}
+				if !handled {
+					var sp savepoint
+					if dec.continueOnError {
+						sp = dec.savepoint(start)
+					}
+					err = j.UnmarshalObject(dec, k)
+					if dec.continueOnError && (err != nil || dec.err != sp.err) {
+						if err := dec.recoverField(sp, k, err); err != nil {
+							return 0, err
+						}
+					} else if err != nil {
+						return 0, err
+					} else if dec.called&1 == 0 {
+						err := dec.skipData()
+						if err != nil {
+							return 0, err
+						}
+						if captured != nil {
+							*captured = append(*captured, ':')
+							*captured = append(*captured, dec.data[valueStart:dec.cursor]...)
+							mark = len(*captured)
+						}
+					} else {
+						dec.keysDone++
+					}
+				}
+				if captured != nil {
+					*captured = (*captured)[:mark]
 				}
 				dec.called &= 0
+				if dec.maxValueBytes > 0 && dec.cursor-start > dec.maxValueBytes {
+					return 0, dec.valueLimitError(start)
+				}
+				if dec.fieldHook != nil {
+					dec.fieldHook(k, start, dec.cursor)
+				}
 			}
 			// will get to that point when keysDone is not lower than keys anymore
 			// in that case, we make sure cursor goes to the end of object, but we skip
@@ -69,11 +151,152 @@ func (dec *Decoder) DecodeObject(j UnmarshalerObject) (int, error) {
 	return 0, InvalidJSONError("Invalid JSON while paring object")
 }
 
+// FindKey reads the next JSON object from its input looking for the key target, values of other keys are skipped.
+//
+// If target is found, FindKey returns true and the decoder is left positioned at its value,
+// which can then be decoded, for example with DecodeString. Otherwise FindKey returns false
+// once the end of the object is reached. If the JSON value is null, false is returned.
+func (dec *Decoder) FindKey(target string) (bool, error) {
+	switch c := dec.nextChar(); c {
+	case '{':
+		dec.cursor = dec.cursor + 1
+	case 'n':
+		return false, dec.advance(4)
+	case 0:
+		return false, InvalidJSONError("Invalid JSON while parsing object")
+	default:
+		return false, InvalidTypeError(
+			fmt.Sprintf(
+				"Cannot find key in object, wrong char '%s' found at pos %d",
+				string(c),
+				dec.cursor,
+			),
+		)
+	}
+	for {
+		k, done, err := dec.nextKey()
+		if err != nil {
+			return false, err
+		} else if done {
+			return false, nil
+		}
+		if k == target {
+			return true, nil
+		}
+		if err := dec.skipData(); err != nil {
+			return false, err
+		}
+	}
+}
+
+// SetFieldHook sets a function called for each object field decoded,
+// with the field's key and the start and end position of its value in the decoder's buffer.
+//
+// key is only valid during the call.
+func (dec *Decoder) SetFieldHook(hook func(key string, start, end int)) {
+	dec.fieldHook = hook
+}
+
+// SetKeyTransformDecode sets a function applied to each object key before it is dispatched,
+// for example SnakeToCamel to decode snake_case keys with UnmarshalObject implementations switching on camelCase keys.
+//
+// Keys go through the transform first, then through the migrator set with SetMigrator if any,
+// then are passed to UnmarshalObject. The field hook receives the key passed to UnmarshalObject.
+func (dec *Decoder) SetKeyTransformDecode(transform func(string) string) {
+	dec.keyTransform = transform
+}
+
+// SnakeToCamel converts a snake_case key to camelCase, for example "user_id" becomes "userId".
+// Leading, trailing and repeated underscores are kept, keys without underscores are returned as is.
+func SnakeToCamel(key string) string {
+	if strings.IndexByte(key, '_') < 0 {
+		return key
+	}
+	b := make([]byte, 0, len(key))
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if c == '_' && i > 0 && key[i-1] != '_' && i+1 < len(key) && key[i+1] >= 'a' && key[i+1] <= 'z' {
+			i++
+			b = append(b, key[i]-'a'+'A')
+			continue
+		}
+		b = append(b, c)
+	}
+	return string(b)
+}
+
+// SetMigrator sets a function called for each object key before the key is passed to UnmarshalObject,
+// with the decoder positioned at the key's value. It applies to nested objects as well.
+//
+// It lets legacy keys be remapped or consumed: if handled is false, newKey is passed to UnmarshalObject
+// instead of key. If handled is true, the migrator must have consumed the value, for example by calling
+// one of the Decoder's Decode methods, and UnmarshalObject is not called for that key.
+// A non nil error stops decoding and is returned.
+func (dec *Decoder) SetMigrator(migrator func(key string, dec *Decoder) (newKey string, handled bool, err error)) {
+	dec.migrator = migrator
+}
+
+// SetContinueOnError sets whether decoding an object should go on when one of its fields fails to decode.
+//
+// If continueOnError is true, the decoder records its state before each field value. When the value fails to decode
+// with a recoverable error, such as an InvalidTypeError for a value of the wrong type, the decoder goes back
+// to the start of the value, skips it and resumes at the next key. The error is recorded as a FieldError
+// which can be retrieved with FieldErrors.
+// InvalidJSONError and LimitExceededError are not recoverable, they still stop decoding and are returned.
+func (dec *Decoder) SetContinueOnError(continueOnError bool) {
+	dec.continueOnError = continueOnError
+}
+
+// FieldErrors returns the errors of the fields skipped since SetContinueOnError(true) was called,
+// in the order they were encountered.
+func (dec *Decoder) FieldErrors() []*FieldError {
+	return dec.fieldErrors
+}
+
+// savepoint is the state of the decoder before a field value.
+type savepoint struct {
+	cursor   int
+	keysDone int
+	child    byte
+	err      error
+}
+
+func (dec *Decoder) savepoint(start int) savepoint {
+	return savepoint{start, dec.keysDone, dec.child, dec.err}
+}
+
+// restore puts the decoder back to the state recorded in sp.
+func (dec *Decoder) restore(sp savepoint) {
+	dec.cursor = sp.cursor
+	dec.keysDone = sp.keysDone
+	dec.child = sp.child
+	dec.err = sp.err
+	dec.called &= 0
+}
+
+// recoverField restores the decoder to sp after the value of key failed to decode with err,
+// or set dec.err if err is nil, then skips the value. It returns err if it is not recoverable.
+func (dec *Decoder) recoverField(sp savepoint, key string, err error) error {
+	if err == nil {
+		err = dec.err
+	}
+	switch err.(type) {
+	case InvalidJSONError, LimitExceededError:
+		return err
+	}
+	dec.restore(sp)
+	if skipErr := dec.skipData(); skipErr != nil {
+		return skipErr
+	}
+	dec.fieldErrors = append(dec.fieldErrors, &FieldError{Key: string([]byte(key)), Offset: sp.cursor, Err: err})
+	return nil
+}
+
 func (dec *Decoder) skipObject() (int, error) {
 	var objectsOpen = 1
 	var objectsClosed = 0
 	// var stringOpen byte = 0
-	for j := dec.cursor; j < dec.length; j++ {
+	for j := dec.cursor; j < dec.length || dec.read(); j++ {
 		switch dec.data[j] {
 		case '}':
 			objectsClosed++
@@ -86,7 +309,7 @@ func (dec *Decoder) skipObject() (int, error) {
 			objectsOpen++
 		case '"':
 			j++
-			for ; j < dec.length; j++ {
+			for ; j < dec.length || dec.read(); j++ {
 				if dec.data[j] != '"' {
 					continue
 				}
@@ -96,7 +319,7 @@ func (dec *Decoder) skipObject() (int, error) {
 				// loop backward and count how many anti slash found
 				// to see if string is effectively escaped
 				ct := 1
-				for i := j; i > 0; i-- {
+				for i := j - 2; i > 0; i-- {
 					if dec.data[i] != '\\' {
 						break
 					}
@@ -111,7 +334,7 @@ func (dec *Decoder) skipObject() (int, error) {
 			continue
 		}
 	}
-	return 0, nil
+	return 0, InvalidJSONError("Invalid JSON while skipping object")
 }
 
 func (dec *Decoder) nextKey() (string, bool, error) {
@@ -153,12 +376,10 @@ func (dec *Decoder) skipData() error {
 			continue
 		// is null
 		case 'n', 't':
-			dec.cursor = dec.cursor + 4
-			return nil
+			return dec.advance(4)
 		// is false
 		case 'f':
-			dec.cursor = dec.cursor + 5
-			return nil
+			return dec.advance(5)
 		// is an object
 		case '{':
 			dec.cursor = dec.cursor + 1
@@ -1,7 +1,11 @@
 package gojay
 
 import (
+	"errors"
+	"strconv"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -263,3 +267,411 @@ func TestDecoderObjectInvalidJSON(t *testing.T) {
 	assert.NotNil(t, err, "Err must not be nil as JSON is invalid")
 	assert.IsType(t, InvalidJSONError(""), err, "err message must be 'Invalid JSON'")
 }
+
+func TestDecoderObjectFieldHook(t *testing.T) {
+	json := []byte(`{"test":245,"test3":"string","testSubObj":{"test":121},"unknown":[1,2]}`)
+	type span struct {
+		key   string
+		value string
+	}
+	spans := []span{}
+	dec := newDecoder(nil, 0)
+	defer dec.addToPool()
+	dec.data = json
+	dec.length = len(json)
+	dec.SetFieldHook(func(key string, start, end int) {
+		spans = append(spans, span{key, string(dec.data[start:end])})
+	})
+	v := &TestObj{}
+	_, err := dec.DecodeObject(v)
+	assert.Nil(t, err, "Err must be nil")
+	assert.Equal(t, 245, v.test, "v.test must be equal to 245")
+	assert.Equal(
+		t,
+		[]span{
+			{"test", "245"},
+			{"test3", `"string"`},
+			{"test", "121"},
+			{"testSubObj", `{"test":121}`},
+			{"unknown", "[1,2]"},
+		},
+		spans,
+		"spans must be equal to the expected ones",
+	)
+}
+
+type testMaxValueBytes struct {
+	name string
+}
+
+func (t *testMaxValueBytes) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "name":
+		return dec.AddString(&t.name)
+	}
+	// other keys are skipped
+	return nil
+}
+
+func (t *testMaxValueBytes) NKeys() int {
+	return 3
+}
+
+func TestDecoderMaxValueBytes(t *testing.T) {
+	big := "[" + strings.Repeat("[1,2,3,4,5],", 20) + "1]"
+	json := `{"name":"small","big":[1,2,` + big + `],"other":"small"}`
+	testCases := []struct {
+		name string
+		max  int
+		err  bool
+	}{
+		{
+			name: "no-limit",
+		},
+		{
+			name: "within-limit",
+			max:  len(big) + 10,
+		},
+		{
+			name: "nested-array-exceeds-limit",
+			max:  64,
+			err:  true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(json))
+			defer dec.addToPool()
+			dec.SetMaxValueBytes(testCase.max)
+			v := &testMaxValueBytes{}
+			_, err := dec.DecodeObject(v)
+			if testCase.err {
+				assert.NotNil(t, err, "err should not be nil")
+				assert.IsType(t, LimitExceededError(""), err, "err should be of type LimitExceededError")
+				return
+			}
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, "small", v.name, "v.name should be decoded")
+		})
+	}
+}
+
+func TestDecodeObjectPrefix(t *testing.T) {
+	testCases := []struct {
+		name      string
+		data      string
+		consumed  int
+		test3     string
+		remaining string
+		err       bool
+	}{
+		{
+			name:      "log-line-suffix",
+			data:      ` {"test":1,"test3":"a\"}"}  INFO request done`,
+			consumed:  26,
+			test3:     `a"}`,
+			remaining: `  INFO request done`,
+		},
+		{
+			name:      "nested-and-json-following",
+			data:      `{"test":1,"other":{"a":[{"b":"}"}]},"test3":"b"}{"test":2}`,
+			consumed:  48,
+			test3:     "b",
+			remaining: `{"test":2}`,
+		},
+		{
+			name:      "escaped-backslash-in-skipped-object",
+			data:      `{"other":{"a":"x\\"},"test3":"d"} tail`,
+			consumed:  33,
+			test3:     "d",
+			remaining: ` tail`,
+		},
+		{
+			name:     "no-trailing-data",
+			data:     `{"test3":"c"}`,
+			consumed: 13,
+			test3:    "c",
+		},
+		{
+			name: "text-before-object",
+			data: `INFO {"test":1}`,
+			err:  true,
+		},
+		{
+			name: "unterminated-object",
+			data: `{"test":1`,
+			err:  true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			data := []byte(testCase.data)
+			v := &TestObj{}
+			consumed, err := DecodeObjectPrefix(data, v)
+			if testCase.err {
+				assert.NotNil(t, err, "err should not be nil")
+				return
+			}
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.consumed, consumed, "consumed should be the expected one")
+			assert.Equal(t, testCase.test3, v.test3, "v.test3 should be decoded")
+			assert.Equal(t, testCase.remaining, string(data[consumed:]), "remaining data should be untouched")
+		})
+	}
+	_, err := DecodeObjectPrefix([]byte(`[1,2] trailing`), &TestObj{})
+	assert.IsType(t, &TypeMismatchError{}, err, "err should be of type *TypeMismatchError")
+}
+
+type testMigratedObj struct {
+	fullName  string
+	firstName string
+	lastName  string
+	age       int
+}
+
+func (t *testMigratedObj) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "fullName":
+		return dec.AddString(&t.fullName)
+	case "age":
+		return dec.AddInt(&t.age)
+	}
+	return nil
+}
+
+func (t *testMigratedObj) NKeys() int {
+	return 2
+}
+
+func TestDecoderMigrator(t *testing.T) {
+	v := &testMigratedObj{}
+	dec := NewDecoder(strings.NewReader(`{"name":"John Doe","years":"42","age2":1,"unknown":{"a":1}}`))
+	defer dec.addToPool()
+	dec.SetMigrator(func(key string, dec *Decoder) (string, bool, error) {
+		switch key {
+		case "name":
+			// renamed key
+			return "fullName", false, nil
+		case "years":
+			// type changed from string to int
+			var s string
+			if err := dec.DecodeString(&s); err != nil {
+				return "", false, err
+			}
+			age, err := strconv.Atoi(s)
+			if err != nil {
+				return "", false, err
+			}
+			v.age = age
+			return key, true, nil
+		case "age2":
+			return "", false, errors.New("age2 is not supported anymore")
+		}
+		return key, false, nil
+	})
+	_, err := dec.DecodeObject(v)
+	assert.NotNil(t, err, "err should not be nil")
+	assert.Equal(t, "age2 is not supported anymore", err.Error(), "err should be the one returned by the migrator")
+	assert.Equal(t, "John Doe", v.fullName, "v.fullName should be decoded from the renamed key")
+	assert.Equal(t, 42, v.age, "v.age should be decoded by the migrator")
+}
+
+func TestDecoderFindKey(t *testing.T) {
+	json := `{"a":{"b":[1,2,{"target":0}]},"c":"string","target":"found","d":[1,2,3]}`
+	dec := NewDecoder(iotest.OneByteReader(strings.NewReader(json)))
+	defer dec.addToPool()
+	found, err := dec.FindKey("target")
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, found, "target should be found")
+	var v string
+	err = dec.DecodeString(&v)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "found", v, "decoder should be positioned at the value of target")
+
+	testCases := []struct {
+		name    string
+		json    string
+		errType interface{}
+	}{
+		{name: "not-found", json: `{"a":1,"b":{"target":1}}`},
+		{name: "empty-object", json: `{}`},
+		{name: "null", json: `null`},
+		{name: "not-an-object", json: `[1,2]`, errType: InvalidTypeError("")},
+		{name: "unterminated-object", json: `{"a":1`, errType: InvalidJSONError("")},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(testCase.json))
+			defer dec.addToPool()
+			found, err := dec.FindKey("target")
+			assert.False(t, found, "target should not be found")
+			if testCase.errType != nil {
+				assert.NotNil(t, err, "err should not be nil")
+				assert.IsType(t, testCase.errType, err, "err should be of the expected type")
+				return
+			}
+			assert.Nil(t, err, "err should be nil")
+		})
+	}
+}
+
+func TestSnakeToCamel(t *testing.T) {
+	testCases := map[string]string{
+		"user_id":        "userId",
+		"userId":         "userId",
+		"a_b_c":          "aBC",
+		"_private":       "_private",
+		"trailing_":      "trailing_",
+		"double__under":  "double__under",
+		"with_2_numbers": "with_2Numbers",
+		"":               "",
+	}
+	for in, expected := range testCases {
+		assert.Equal(t, expected, SnakeToCamel(in), "SnakeToCamel result should be the expected one")
+	}
+}
+
+func TestDecoderKeyTransform(t *testing.T) {
+	v := &testMigratedObj{}
+	dec := NewDecoder(strings.NewReader(`{"full_name":"John Doe","old_age":42}`))
+	defer dec.addToPool()
+	dec.SetKeyTransformDecode(SnakeToCamel)
+	dec.SetMigrator(func(key string, dec *Decoder) (string, bool, error) {
+		// keys are transformed before the migrator is called
+		if key == "oldAge" {
+			return "age", false, nil
+		}
+		return key, false, nil
+	})
+	var hooked []string
+	dec.SetFieldHook(func(key string, start, end int) {
+		hooked = append(hooked, key)
+	})
+	_, err := dec.DecodeObject(v)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "John Doe", v.fullName, "v.fullName should be decoded from the snake_case key")
+	assert.Equal(t, 42, v.age, "v.age should be decoded from the migrated key")
+	assert.Equal(t, []string{"fullName", "age"}, hooked, "field hook should receive the transformed keys")
+}
+
+func TestDecoderObjectCapture(t *testing.T) {
+	json := `{"id": 1, "test":"a","nested":{"test":"b"} ,"test2":"c","arr":[1, "x\"y"],"flag":true}`
+	dec := NewDecoder(iotest.OneByteReader(strings.NewReader(json)))
+	defer dec.addToPool()
+	v := &jsonDecodePartial{}
+	rest, err := dec.DecodeObjectCapture(v)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "a", v.Test, "v.Test should be decoded")
+	assert.Equal(t, "c", v.Test2, "v.Test2 should be decoded")
+	assert.Equal(
+		t,
+		`"id": 1,"nested":{"test":"b"},"arr":[1, "x\"y"],"flag":true`,
+		string(rest),
+		"captured fields should be the raw fields not decoded",
+	)
+	r, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("test", v.Test+"!")
+		enc.AddStringKey("test2", v.Test2)
+		enc.AddEmbeddedJSON(rest)
+	}))
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(
+		t,
+		`{"test":"a!","test2":"c","id": 1,"nested":{"test":"b"},"arr":[1, "x\"y"],"flag":true}`,
+		string(r),
+		"captured fields should be spliced back",
+	)
+
+	dec = NewDecoder(strings.NewReader(`{"x\"y":"q\"r","test":"a","b\\n":2}`))
+	defer dec.addToPool()
+	v = &jsonDecodePartial{}
+	rest, err = dec.DecodeObjectCapture(v)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "a", v.Test, "v.Test should be decoded")
+	assert.Equal(t, `"x\"y":"q\"r","b\\n":2`, string(rest), "escaped keys should be captured raw")
+
+	dec = NewDecoder(strings.NewReader(`{"test":"a","test2":"b"}`))
+	defer dec.addToPool()
+	rest, err = dec.DecodeObjectCapture(&jsonDecodePartial{})
+	assert.Nil(t, err, "err should be nil")
+	assert.Nil(t, rest, "nothing should be captured")
+
+	dec = NewDecoder(strings.NewReader(`{"test":"a","other":[1,2`))
+	defer dec.addToPool()
+	_, err = dec.DecodeObjectCapture(&jsonDecodePartial{})
+	assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+}
+
+type testRecoverObj struct {
+	a int
+	b string
+}
+
+func (t *testRecoverObj) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "fail":
+		v := &TestSubObj{}
+		if err := dec.AddObject(v); err != nil {
+			return err
+		}
+		return errors.New("invalid value")
+	case "a":
+		return dec.AddInt(&t.a)
+	case "b":
+		return dec.AddString(&t.b)
+	}
+	return nil
+}
+
+func (t *testRecoverObj) NKeys() int {
+	return 3
+}
+
+func TestDecoderContinueOnError(t *testing.T) {
+	json := `{"test":"str","test2":2,"test3":12,"testSubObj":{"test":1,"test2":"x","test3":"sub"},` +
+		`"testSubObj2":[1,2],"test4":"ok","test5":1.5}`
+	v := &TestObj{}
+	dec := NewDecoder(iotest.OneByteReader(strings.NewReader(json)))
+	defer dec.addToPool()
+	dec.SetContinueOnError(true)
+	err := dec.Decode(v)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 2, v.test2, "v.test2 should be decoded")
+	assert.Equal(t, "ok", v.test4, "v.test4 should be decoded")
+	assert.Equal(t, 1.5, v.test5, "v.test5 should be decoded")
+	assert.Equal(t, 1, v.testSubObj.test3, "v.testSubObj.test3 should be decoded")
+	assert.Equal(t, "sub", v.testSubObj.test5, "v.testSubObj.test5 should be decoded")
+	fieldErrs := dec.FieldErrors()
+	assert.Len(t, fieldErrs, 4, "4 fields should have failed")
+	for i, expected := range []struct {
+		key    string
+		offset int
+	}{{"test", 8}, {"test3", 32}, {"test2", 66}, {"testSubObj2", 99}} {
+		assert.Equal(t, expected.key, fieldErrs[i].Key, "key should be the one of the failed field")
+		assert.Equal(t, expected.offset, fieldErrs[i].Offset, "offset should be the start of the failed value")
+		assert.IsType(t, InvalidTypeError(""), fieldErrs[i].Err, "err should be of type InvalidTypeError")
+	}
+
+	r := &testRecoverObj{}
+	dec = NewDecoder(strings.NewReader(`{"fail":{"x":[1,2]},"a":1,"b":"kept"}`))
+	defer dec.addToPool()
+	dec.SetContinueOnError(true)
+	err = dec.Decode(r)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 1, r.a, "r.a should be decoded")
+	assert.Equal(t, "kept", r.b, "r.b should be decoded")
+	assert.Len(t, dec.FieldErrors(), 1, "1 field should have failed")
+	assert.Equal(t, `Cannot decode key "fail" at pos 8: invalid value`, dec.FieldErrors()[0].Error())
+
+	dec = NewDecoder(strings.NewReader(`{"test":"str","test3":"unterminated}`))
+	defer dec.addToPool()
+	dec.SetContinueOnError(true)
+	err = dec.Decode(&TestObj{})
+	assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+
+	dec = NewDecoder(strings.NewReader(`{"test":"str","test2":2}`))
+	defer dec.addToPool()
+	err = dec.Decode(&TestObj{})
+	assert.Nil(t, err, "err should be nil")
+	assert.Nil(t, dec.FieldErrors(), "field errors should only be recorded once enabled")
+}
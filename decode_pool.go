@@ -7,23 +7,41 @@ var decPool = make(chan *Decoder, 16)
 // NewDecoder returns a new decoder or borrows one from the pool
 // it takes an io.Reader implementation as data input
 func NewDecoder(r io.Reader) *Decoder {
-	return newDecoder(r, 512)
+	return newDecoder(r, poolConfig.DecoderBufferSize)
 }
 
 func newDecoder(r io.Reader, bufSize int) *Decoder {
 	select {
 	case dec := <-decPool:
+		if m := metrics(); m != nil {
+			m.PoolGet(PoolDecoder, true)
+		}
 		dec.called = 0
 		dec.keysDone = 0
 		dec.cursor = 0
 		dec.err = nil
 		dec.r = r
 		dec.length = 0
+		dec.fieldHook = nil
+		dec.maxValueBytes = 0
+		dec.maxExponent = 0
+		dec.migrator = nil
+		dec.keyTransform = nil
+		dec.continueOnError = false
+		dec.fieldErrors = nil
 		if bufSize > 0 {
-			dec.data = make([]byte, bufSize)
+			// the buffer of the pooled decoder is reused when it is large enough
+			if cap(dec.data) >= bufSize {
+				dec.data = dec.data[:bufSize]
+			} else {
+				dec.data = make([]byte, bufSize)
+			}
 		}
 		return dec
 	default:
+		if m := metrics(); m != nil {
+			m.PoolGet(PoolDecoder, false)
+		}
 		dec := &Decoder{
 			called:   0,
 			cursor:   0,
@@ -40,6 +58,15 @@ func newDecoder(r io.Reader, bufSize int) *Decoder {
 }
 
 func (dec *Decoder) addToPool() {
+	if dec.r == nil {
+		// without io.Reader, data is the input given to the decoder, it must not be reused as a buffer
+		dec.data = nil
+	} else if poolConfig.MaxBufferSize > 0 && cap(dec.data) > poolConfig.MaxBufferSize {
+		if m := metrics(); m != nil {
+			m.BufferDropped(PoolDecoder, cap(dec.data))
+		}
+		dec.data = nil
+	}
 	select {
 	case decPool <- dec:
 	default:
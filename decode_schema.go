@@ -0,0 +1,126 @@
+package gojay
+
+// Schema describes the keys of a JSON object, by key.
+type Schema map[string]SchemaField
+
+// SchemaField describes the value of a key of a Schema.
+//
+// Kind is the expected Kind of the value, 0 accepting values of any kind.
+// A null value is accepted for any Kind if the key is not Required.
+type SchemaField struct {
+	Kind     Kind
+	Required bool
+}
+
+// ViolationType is the type of a schema Violation.
+type ViolationType int
+
+// Types of schema violations.
+const (
+	// ViolationMissing is the violation of a required key missing from the object
+	ViolationMissing ViolationType = iota + 1
+	// ViolationUnexpected is the violation of a key not in the schema
+	ViolationUnexpected
+	// ViolationKindMismatch is the violation of a value not of the Kind expected by the schema
+	ViolationKindMismatch
+)
+
+// Violation is a key of a JSON object not conforming to a Schema.
+//
+// Offset is the position of the value in the decoder's input, -1 for a missing key.
+// Expected and Actual are only set for a ViolationKindMismatch.
+type Violation struct {
+	Type     ViolationType
+	Key      string
+	Offset   int
+	Expected Kind
+	Actual   Kind
+}
+
+// ValidationReport holds all the violations found while decoding an object with DecodeWithSchema.
+type ValidationReport struct {
+	Violations []Violation
+}
+
+// Valid reports whether the object conforms to its schema.
+func (r ValidationReport) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+// DecodeWithSchema reads the next JSON object from its input, validates it against schema and decodes the keys
+// conforming to it to dst.
+//
+// Decoding does not stop at the first violation, all of them are returned in the report:
+// keys of the schema missing from the object, keys not in the schema and values not of the expected Kind.
+// Values of keys violating the schema are skipped without being passed to dst. Missing keys are reported
+// in byte-wise order, other violations in the order they are found.
+// Only the keys of the object itself are validated, not the ones of nested objects.
+//
+// err is only set if the JSON is invalid, or if the value is not an object, in which case it is a *TypeMismatchError.
+func (dec *Decoder) DecodeWithSchema(schema Schema, dst UnmarshalerObject) (report ValidationReport, err error) {
+	if err := dec.expectKind(KindObject); err != nil {
+		return report, err
+	}
+	if dec.nextChar() == 'n' {
+		return report, dec.advance(4)
+	}
+	v := &schemaObject{
+		schema: schema,
+		dst:    dst,
+		seen:   make(map[string]struct{}, len(schema)),
+	}
+	prevErr := dec.err
+	if err := dec.AddObject(v); err != nil {
+		return report, err
+	}
+	if dec.err != prevErr {
+		return report, dec.err
+	}
+	var missing []string
+	for key, field := range schema {
+		if _, ok := v.seen[key]; field.Required && !ok {
+			missing = append(missing, key)
+		}
+	}
+	sortKeys(missing)
+	for _, key := range missing {
+		v.violations = append(v.violations, Violation{Type: ViolationMissing, Key: key, Offset: -1})
+	}
+	report.Violations = v.violations
+	return report, nil
+}
+
+// schemaObject is an UnmarshalerObject passing to dst the keys conforming to schema
+type schemaObject struct {
+	schema     Schema
+	dst        UnmarshalerObject
+	seen       map[string]struct{}
+	violations []Violation
+}
+
+func (s *schemaObject) UnmarshalObject(dec *Decoder, key string) error {
+	field, ok := s.schema[key]
+	if !ok {
+		dec.nextChar()
+		s.violations = append(s.violations, Violation{Type: ViolationUnexpected, Key: string([]byte(key)), Offset: dec.cursor})
+		return nil
+	}
+	s.seen[key] = struct{}{}
+	actual := kindOf(dec.nextChar())
+	if field.Kind != 0 && actual != field.Kind && (actual != KindNull || field.Required) {
+		s.violations = append(s.violations, Violation{
+			Type:     ViolationKindMismatch,
+			Key:      string([]byte(key)),
+			Offset:   dec.cursor,
+			Expected: field.Kind,
+			Actual:   actual,
+		})
+		return nil
+	}
+	return s.dst.UnmarshalObject(dec, key)
+}
+
+func (s *schemaObject) NKeys() int {
+	// all keys must be read to find the unexpected ones
+	return int(^uint(0) >> 1)
+}
@@ -0,0 +1,71 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSchema = Schema{
+	"test":       {Kind: KindNumber, Required: true},
+	"test2":      {Kind: KindNumber, Required: true},
+	"test3":      {Kind: KindString},
+	"test4":      {Kind: KindString, Required: true},
+	"test5":      {Kind: KindNumber},
+	"testSubObj": {Kind: KindObject},
+	"testArr":    {},
+}
+
+func TestDecoderDecodeWithSchema(t *testing.T) {
+	json := `{"test":1,"test3":"s","extra":[1,2],"test5":"1.5","testSubObj":null,"testArr":[{"test":2}],"other":true}`
+	dec := NewDecoder(strings.NewReader(json))
+	defer dec.addToPool()
+	v := &TestObj{}
+	report, err := dec.DecodeWithSchema(testSchema, v)
+	assert.Nil(t, err, "err should be nil")
+	assert.False(t, report.Valid(), "report should not be valid")
+	assert.Equal(
+		t,
+		[]Violation{
+			{Type: ViolationUnexpected, Key: "extra", Offset: 30},
+			{Type: ViolationKindMismatch, Key: "test5", Offset: 44, Expected: KindNumber, Actual: KindString},
+			{Type: ViolationUnexpected, Key: "other", Offset: 99},
+			{Type: ViolationMissing, Key: "test2", Offset: -1},
+			{Type: ViolationMissing, Key: "test4", Offset: -1},
+		},
+		report.Violations,
+		"violations should be the expected ones",
+	)
+	assert.Equal(t, 1, v.test, "v.test should be decoded")
+	assert.Equal(t, "s", v.test3, "v.test3 should be decoded")
+	assert.Equal(t, 0.0, v.test5, "v.test5 should not be decoded")
+	assert.Len(t, v.testArr, 1, "v.testArr should be decoded")
+
+	dec = NewDecoder(strings.NewReader(`{"test":1,"test2":2,"test4":"s"}`))
+	defer dec.addToPool()
+	report, err = dec.DecodeWithSchema(testSchema, &TestObj{})
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, report.Valid(), "report should be valid")
+
+	dec = NewDecoder(strings.NewReader(`{"test":null,"test2":2,"test4":"s","test3":null}`))
+	defer dec.addToPool()
+	report, err = dec.DecodeWithSchema(testSchema, &TestObj{})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(
+		t,
+		[]Violation{{Type: ViolationKindMismatch, Key: "test", Offset: 8, Expected: KindNumber, Actual: KindNull}},
+		report.Violations,
+		"null should only be accepted for keys not required",
+	)
+
+	dec = NewDecoder(strings.NewReader(`[1]`))
+	defer dec.addToPool()
+	_, err = dec.DecodeWithSchema(testSchema, &TestObj{})
+	assert.IsType(t, &TypeMismatchError{}, err, "err should be of type *TypeMismatchError")
+
+	dec = NewDecoder(strings.NewReader(`{"test":1,"extra":[1,`))
+	defer dec.addToPool()
+	_, err = dec.DecodeWithSchema(testSchema, &TestObj{})
+	assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+}
@@ -0,0 +1,67 @@
+package gojay
+
+import "fmt"
+
+// ValidateShape scans the next JSON object from its input and checks the kind of the value
+// of each key present in spec, values are skipped without being decoded.
+// It returns the keys whose value kind does not match the one in spec, keys absent from spec are ignored.
+// As for decoding, a null value is accepted for any kind.
+//
+// The decoder is rewound once the object is scanned so that it can be decoded afterwards.
+func (dec *Decoder) ValidateShape(spec map[string]Kind) ([]string, error) {
+	start := dec.cursor
+	mismatches, err := dec.scanShape(spec)
+	dec.cursor = start
+	return mismatches, err
+}
+
+func (dec *Decoder) scanShape(spec map[string]Kind) ([]string, error) {
+	var mismatches []string
+	switch c := dec.nextChar(); c {
+	case '{':
+		dec.cursor = dec.cursor + 1
+	case 'n':
+		return nil, nil
+	case 0:
+		return nil, InvalidJSONError("Invalid JSON while validating object shape")
+	default:
+		return nil, InvalidTypeError(
+			fmt.Sprintf(
+				"Cannot validate object shape, wrong char '%s' found at pos %d",
+				string(c),
+				dec.cursor,
+			),
+		)
+	}
+	for {
+		switch dec.nextChar() {
+		case '}':
+			dec.cursor = dec.cursor + 1
+			return mismatches, nil
+		case '"':
+			// keys are skipped instead of being parsed as parsing unescapes them in place
+			// which would prevent the object from being decoded once rewound
+			dec.cursor = dec.cursor + 1
+			keyStart := dec.cursor
+			if err := dec.skipString(); err != nil {
+				return nil, err
+			}
+			keyEnd := dec.cursor - 1
+			if dec.nextChar() != ':' {
+				return nil, InvalidJSONError("Invalid JSON while parsing object key")
+			}
+			dec.cursor = dec.cursor + 1
+			c := dec.nextChar()
+			if kind, ok := spec[string(dec.data[keyStart:keyEnd])]; ok {
+				if valueKind := kindOf(c); valueKind != KindNull && valueKind != kind {
+					mismatches = append(mismatches, string(dec.data[keyStart:keyEnd]))
+				}
+			}
+			if err := dec.skipData(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, InvalidJSONError("Invalid JSON while validating object shape")
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoderValidateShape(t *testing.T) {
+	spec := map[string]Kind{
+		"test":       KindNumber,
+		"test3":      KindString,
+		"testArr":    KindArray,
+		"testSubObj": KindObject,
+		"testBool":   KindBool,
+	}
+	testCases := []struct {
+		name       string
+		json       string
+		mismatches []string
+		errType    interface{}
+	}{
+		{
+			name:       "valid",
+			json:       `{"test":1,"test3":"string","testArr":[],"testSubObj":{"test":1},"testBool":true,"other":"x"}`,
+			mismatches: nil,
+		},
+		{
+			name:       "valid-with-nulls",
+			json:       `{"test":null,"test3":null}`,
+			mismatches: nil,
+		},
+		{
+			name:       "mismatches",
+			json:       `{"test":"1","test3":2,"testArr":{},"testSubObj":[1],"testBool":"true"}`,
+			mismatches: []string{"test", "test3", "testArr", "testSubObj", "testBool"},
+		},
+		{
+			name:       "null",
+			json:       `null`,
+			mismatches: nil,
+		},
+		{
+			name:    "not-an-object",
+			json:    `[1,2]`,
+			errType: InvalidTypeError(""),
+		},
+		{
+			name:    "invalid-json",
+			json:    `{"test" 1}`,
+			errType: InvalidJSONError(""),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(testCase.json))
+			defer dec.addToPool()
+			mismatches, err := dec.ValidateShape(spec)
+			if testCase.errType != nil {
+				assert.NotNil(t, err, "err should not be nil")
+				assert.IsType(t, testCase.errType, err, "err should be of the expected type")
+				return
+			}
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.mismatches, mismatches, "mismatches should be equal to the expected ones")
+		})
+	}
+}
+
+func TestDecoderValidateShapeThenDecode(t *testing.T) {
+	json := `{"test":245,"test4":"escaped \"quote\"","test3":"string"}`
+	dec := NewDecoder(strings.NewReader(json))
+	defer dec.addToPool()
+	mismatches, err := dec.ValidateShape(map[string]Kind{"test": KindNumber, "test3": KindString})
+	assert.Nil(t, err, "err should be nil")
+	assert.Nil(t, mismatches, "mismatches should be nil")
+	v := &TestObj{}
+	err = dec.Decode(v)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 245, v.test, "v.test must be equal to 245")
+	assert.Equal(t, "string", v.test3, "v.test3 must be equal to 'string'")
+	assert.Equal(t, `escaped "quote"`, v.test4, "v.test4 must be equal to the expected value")
+}
+
+func TestKindString(t *testing.T) {
+	assert.Equal(t, "string", KindString.String(), "kind name should be 'string'")
+	assert.Equal(t, "array", KindArray.String(), "kind name should be 'array'")
+	assert.Equal(t, "invalid", Kind(0).String(), "kind name should be 'invalid'")
+}
@@ -23,7 +23,7 @@ type StreamDecoder struct {
 // It takes an io.Reader implementation as data input.
 // It initiates the done channel returned by Done().
 func (s stream) NewDecoder(r io.Reader) *StreamDecoder {
-	dec := newDecoder(r, 512)
+	dec := newDecoder(r, poolConfig.DecoderBufferSize)
 	streamDec := &StreamDecoder{
 		Decoder: dec,
 		done:    make(chan struct{}, 1),
@@ -2,6 +2,7 @@ package gojay
 
 import (
 	"fmt"
+	"net/url"
 	"unsafe"
 )
 
@@ -47,6 +48,24 @@ func (dec *Decoder) DecodeString(v *string) error {
 	return nil
 }
 
+// DecodeStringURLEncoded reads the next JSON-encoded value from its input, a percent-encoded string,
+// and stores it unescaped as by url.QueryUnescape in the string pointed to by v.
+//
+// The JSON string is unescaped first, then its percent-encoding is decoded.
+// If the string is not a valid percent-encoded string, an InvalidTypeError is returned.
+func (dec *Decoder) DecodeStringURLEncoded(v *string) error {
+	var s string
+	if err := dec.DecodeString(&s); err != nil {
+		return err
+	}
+	unescaped, err := url.QueryUnescape(s)
+	if err != nil {
+		return InvalidTypeError(fmt.Sprintf("Cannot unmarshall to string, invalid percent-encoding: %s", err.Error()))
+	}
+	*v = unescaped
+	return nil
+}
+
 func (dec *Decoder) parseEscapedString() error {
 	// know where to stop slash
 	start := dec.cursor
@@ -62,7 +81,7 @@ func (dec *Decoder) parseEscapedString() error {
 					return InvalidJSONError("Invalid JSON unescaped character")
 				}
 				diff := (nSlash - 1) >> 1
-				dec.data = append(dec.data[:start+diff-1], dec.data[dec.cursor-1:]...)
+				dec.data = append(dec.data[:start+diff-1], dec.data[dec.cursor-1:dec.length]...)
 				dec.length = len(dec.data)
 				dec.cursor -= nSlash - diff
 				return nil
@@ -74,10 +93,10 @@ func (dec *Decoder) parseEscapedString() error {
 				var diff int
 				if nSlash&1 == 1 {
 					diff = (nSlash - 1) >> 1
-					dec.data = append(dec.data[:start+diff], dec.data[dec.cursor-1:]...)
+					dec.data = append(dec.data[:start+diff], dec.data[dec.cursor-1:dec.length]...)
 				} else {
 					diff = nSlash >> 1
-					dec.data = append(dec.data[:start+diff-1], dec.data[dec.cursor-1:]...)
+					dec.data = append(dec.data[:start+diff-1], dec.data[dec.cursor-1:dec.length]...)
 				}
 				dec.length = len(dec.data)
 				dec.cursor -= nSlash - diff
@@ -88,7 +107,7 @@ func (dec *Decoder) parseEscapedString() error {
 					return InvalidJSONError("Invalid JSON unescaped character")
 				}
 				diff := nSlash >> 1
-				dec.data = append(dec.data[:start+diff-1], dec.data[dec.cursor-1:]...)
+				dec.data = append(dec.data[:start+diff-1], dec.data[dec.cursor-1:dec.length]...)
 				dec.length = len(dec.data)
 				dec.cursor -= (nSlash - diff)
 				return nil
@@ -123,30 +142,15 @@ func (dec *Decoder) getString() (int, int, error) {
 	return 0, 0, InvalidJSONError("Invalid JSON while parsing string")
 }
 
+// skipEscapedString skips the char following a backslash in a string, which must be a valid escape char.
 func (dec *Decoder) skipEscapedString() error {
-	start := dec.cursor
-	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
-		if dec.data[dec.cursor] != '\\' {
-			d := dec.data[dec.cursor]
+	if dec.cursor < dec.length || dec.read() {
+		switch dec.data[dec.cursor] {
+		case '"', '\\', '/', 'b', 'f', 'n', 'r', 't', 'u':
 			dec.cursor = dec.cursor + 1
-			nSlash := dec.cursor - start
-			switch d {
-			case '"':
-				// nSlash must be odd
-				if nSlash&1 != 1 {
-					return InvalidJSONError("Invalid JSON unescaped character")
-				}
-				return nil
-			case 'n', 'r', 't':
-				return nil
-			default:
-				// nSlash must be even
-				if nSlash&1 == 1 {
-					return InvalidJSONError("Invalid JSON unescaped character")
-				}
-				return nil
-			}
+			return nil
 		}
+		return InvalidJSONError("Invalid JSON unescaped character")
 	}
 	return nil
 }
@@ -45,3 +45,31 @@ func TestDecoderStringInvalidType(t *testing.T) {
 	assert.NotNil(t, err, "Err must not be nil as JSON is invalid")
 	assert.IsType(t, InvalidTypeError(""), err, "err message must be 'Invalid JSON'")
 }
+
+type testDecodeURLEncoded struct {
+	query string
+}
+
+func (t *testDecodeURLEncoded) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "query":
+		return dec.AddStringURLEncoded(&t.query)
+	}
+	return nil
+}
+
+func (t *testDecodeURLEncoded) NKeys() int {
+	return 1
+}
+
+func TestDecoderStringURLEncoded(t *testing.T) {
+	v := &testDecodeURLEncoded{}
+	err := UnmarshalObject([]byte(`{"query":"q%3Da+b%26c%3D%22d%22%2F%C3%A9"}`), v)
+	assert.Nil(t, err, "Err must be nil")
+	assert.Equal(t, `q=a b&c="d"/é`, v.query, "v.query must be unescaped")
+
+	v = &testDecodeURLEncoded{}
+	err = UnmarshalObject([]byte(`{"query":"invalid %zz"}`), v)
+	assert.NotNil(t, err, "Err must not be nil")
+	assert.IsType(t, InvalidTypeError(""), err, "err must be of type InvalidTypeError")
+}
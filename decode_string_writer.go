@@ -0,0 +1,234 @@
+package gojay
+
+import (
+	"fmt"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const stringChunkSize = 512
+
+// DecodeStringTo reads the next JSON-encoded string from its input, unescapes it and writes it to w.
+//
+// The string is written in chunks as it is read, it is never held entirely in memory,
+// when the Decoder reads from an io.Reader the bytes of the string already written are discarded from its buffer.
+// They are kept if a field hook, a value size limit or SetContinueOnError needs the whole value to be in the buffer.
+// If the JSON value is null, nothing is written.
+func (dec *Decoder) DecodeStringTo(w io.Writer) error {
+	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
+		switch dec.data[dec.cursor] {
+		case ' ', '\n', '\t', '\r', ',':
+			continue
+		case '"':
+			dec.cursor = dec.cursor + 1
+			s := stringStream{w: w, start: dec.cursor}
+			return s.stream(dec)
+		// is nil
+		case 'n':
+			dec.cursor = dec.cursor + 4
+			return nil
+		default:
+			dec.err = InvalidTypeError(
+				fmt.Sprintf(
+					"Cannot unmarshall to string, wrong char '%s' found at pos %d",
+					string(dec.data[dec.cursor]),
+					dec.cursor,
+				),
+			)
+			err := dec.skipData()
+			if err != nil {
+				return err
+			}
+			return nil
+		}
+	}
+	return InvalidJSONError("Invalid JSON while parsing string")
+}
+
+// AddStringTo decodes the next key, a string, and writes it unescaped to w.
+// If next key is not a JSON string nor null, InvalidTypeError will be returned.
+func (dec *Decoder) AddStringTo(w io.Writer) error {
+	err := dec.DecodeStringTo(w)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
+
+const (
+	stringStateChar = iota
+	stringStateEscape
+	stringStateUnicode
+)
+
+// stringStream unescapes a string from the decoder and writes it in chunks to w
+type stringStream struct {
+	w io.Writer
+	// start is the position of the string in the buffer, the bytes before it are never discarded
+	// as the values being decoded may still refer to them
+	start int
+	chunk [stringChunkSize]byte
+	n     int
+	// state is kept between reads so escape sequences can be split across them
+	state int
+	u     rune
+	nHex  int
+	high  rune
+}
+
+func (s *stringStream) stream(dec *Decoder) error {
+	for {
+		if dec.cursor >= dec.length {
+			// the string read so far has been processed,
+			// drop it to keep the buffer from growing
+			if dec.r != nil && dec.fieldHook == nil && dec.maxValueBytes == 0 && !dec.continueOnError {
+				dec.cursor = s.start
+				dec.length = s.start
+			}
+			if !dec.read() {
+				return InvalidJSONError("Invalid JSON while parsing string")
+			}
+		}
+		c := dec.data[dec.cursor]
+		dec.cursor = dec.cursor + 1
+		switch s.state {
+		case stringStateChar:
+			if s.high != 0 && c != '\\' {
+				s.high = 0
+				if err := s.writeRune(utf8.RuneError); err != nil {
+					return err
+				}
+			}
+			switch c {
+			case '"':
+				return s.flush()
+			case '\\':
+				s.state = stringStateEscape
+			default:
+				if err := s.writeByte(c); err != nil {
+					return err
+				}
+			}
+		case stringStateEscape:
+			if s.high != 0 && c != 'u' {
+				s.high = 0
+				if err := s.writeRune(utf8.RuneError); err != nil {
+					return err
+				}
+			}
+			s.state = stringStateChar
+			var err error
+			switch c {
+			case '"', '\\', '/':
+				err = s.writeByte(c)
+			case 'b':
+				err = s.writeByte('\b')
+			case 'f':
+				err = s.writeByte('\f')
+			case 'n':
+				err = s.writeByte('\n')
+			case 'r':
+				err = s.writeByte('\r')
+			case 't':
+				err = s.writeByte('\t')
+			case 'u':
+				s.state = stringStateUnicode
+				s.u = 0
+				s.nHex = 0
+			default:
+				return InvalidJSONError("Invalid JSON unescaped character")
+			}
+			if err != nil {
+				return err
+			}
+		case stringStateUnicode:
+			h := hexValue(c)
+			if h < 0 {
+				return InvalidJSONError("Invalid JSON unicode escape sequence")
+			}
+			s.u = s.u<<4 | h
+			s.nHex++
+			if s.nHex < 4 {
+				continue
+			}
+			s.state = stringStateChar
+			if err := s.writeUnicode(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeUnicode writes the rune of a \uXXXX escape sequence, pairing surrogates
+func (s *stringStream) writeUnicode() error {
+	r := s.u
+	if s.high != 0 {
+		high := s.high
+		s.high = 0
+		if utf16.IsSurrogate(r) && r >= 0xDC00 {
+			return s.writeRune(utf16.DecodeRune(high, r))
+		}
+		if err := s.writeRune(utf8.RuneError); err != nil {
+			return err
+		}
+	}
+	if utf16.IsSurrogate(r) {
+		if r < 0xDC00 {
+			// high surrogate, wait for the low one
+			s.high = r
+			return nil
+		}
+		r = utf8.RuneError
+	}
+	return s.writeRune(r)
+}
+
+func (s *stringStream) writeByte(c byte) error {
+	if s.n == len(s.chunk) {
+		if err := s.flush(); err != nil {
+			return err
+		}
+	}
+	s.chunk[s.n] = c
+	s.n++
+	return nil
+}
+
+func (s *stringStream) writeRune(r rune) error {
+	if s.n+utf8.UTFMax > len(s.chunk) {
+		if err := s.flush(); err != nil {
+			return err
+		}
+	}
+	s.n += utf8.EncodeRune(s.chunk[s.n:], r)
+	return nil
+}
+
+func (s *stringStream) flush() error {
+	if s.high != 0 {
+		s.high = 0
+		if err := s.writeRune(utf8.RuneError); err != nil {
+			return err
+		}
+	}
+	if s.n == 0 {
+		return nil
+	}
+	_, err := s.w.Write(s.chunk[:s.n])
+	s.n = 0
+	return err
+}
+
+func hexValue(c byte) rune {
+	switch {
+	case c >= '0' && c <= '9':
+		return rune(c - '0')
+	case c >= 'a' && c <= 'f':
+		return rune(c-'a') + 10
+	case c >= 'A' && c <= 'F':
+		return rune(c-'A') + 10
+	}
+	return -1
+}
@@ -0,0 +1,202 @@
+package gojay
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testChunksWriter records every chunk written to it
+type testChunksWriter struct {
+	chunks []string
+}
+
+func (w *testChunksWriter) Write(b []byte) (int, error) {
+	w.chunks = append(w.chunks, string(b))
+	return len(b), nil
+}
+
+func (w *testChunksWriter) String() string {
+	return strings.Join(w.chunks, "")
+}
+
+func TestDecoderStringTo(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		expected string
+		errType  interface{}
+	}{
+		{
+			name:     "basic",
+			json:     `"string"`,
+			expected: "string",
+		},
+		{
+			name:     "escape-sequences",
+			json:     `"quote \" backslash \\ slash \/ \b\f\n\r\t end"`,
+			expected: "quote \" backslash \\ slash / \b\f\n\r\t end",
+		},
+		{
+			name:     "utf8",
+			json:     `"été 漢字 😀"`,
+			expected: "été 漢字 😀",
+		},
+		{
+			name:     "unicode-escape-sequences",
+			json:     `"\u00e9t\u00E9 \u6f22\u5b57 \ud83d\ude00"`,
+			expected: "été 漢字 😀",
+		},
+		{
+			name:     "lone-surrogate",
+			json:     `"\ud83d a"`,
+			expected: "\ufffd a",
+		},
+		{
+			name:     "null",
+			json:     `null`,
+			expected: "",
+		},
+		{
+			name:    "invalid-escape",
+			json:    `"\x"`,
+			errType: InvalidJSONError(""),
+		},
+		{
+			name:    "invalid-unicode-escape",
+			json:    `"\u00zz"`,
+			errType: InvalidJSONError(""),
+		},
+		{
+			name:    "unterminated",
+			json:    `"string`,
+			errType: InvalidJSONError(""),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// the one byte reader splits escape sequences across reads
+			dec := NewDecoder(iotest.OneByteReader(strings.NewReader(testCase.json)))
+			defer dec.addToPool()
+			w := &testChunksWriter{}
+			err := dec.DecodeStringTo(w)
+			if testCase.errType != nil {
+				assert.NotNil(t, err, "err should not be nil")
+				assert.IsType(t, testCase.errType, err, "err should be of the expected type")
+				return
+			}
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expected, w.String(), "written string should be equal to the expected one")
+		})
+	}
+}
+
+func TestDecoderStringToInvalidType(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`1`))
+	defer dec.addToPool()
+	err := dec.DecodeStringTo(&bytes.Buffer{})
+	assert.Nil(t, err, "err should be nil")
+	assert.IsType(t, InvalidTypeError(""), dec.err, "dec.err should be of type InvalidTypeError")
+}
+
+func TestDecoderStringToLargeString(t *testing.T) {
+	s := strings.Repeat(`abc\"é\n`, 100000)
+	expected := strings.Repeat("abc\"é\n", 100000)
+	dec := NewDecoder(iotest.HalfReader(strings.NewReader(`"` + s + `"`)))
+	defer dec.addToPool()
+	w := &testChunksWriter{}
+	err := dec.DecodeStringTo(w)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, expected, w.String(), "written string should be equal to the expected one")
+	assert.True(t, len(w.chunks) > 1, "string should be written in multiple chunks")
+	for _, chunk := range w.chunks {
+		assert.True(t, len(chunk) <= stringChunkSize, "chunks should not be bigger than stringChunkSize")
+	}
+	assert.True(t, len(dec.data) <= 1024, "decoder buffer should not grow")
+}
+
+func TestDecoderStringToWriterError(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`"string"`))
+	defer dec.addToPool()
+	testErr := errors.New("test error")
+	err := dec.DecodeStringTo(testErrWriter{testErr})
+	assert.Equal(t, testErr, err, "err should be the one returned by the writer")
+}
+
+type testErrWriter struct {
+	err error
+}
+
+func (w testErrWriter) Write(b []byte) (int, error) {
+	return 0, w.err
+}
+
+type testStringToObj struct {
+	id   int
+	blob *bytes.Buffer
+	name string
+}
+
+func (t *testStringToObj) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "id":
+		return dec.AddInt(&t.id)
+	case "blob":
+		return dec.AddStringTo(t.blob)
+	case "name":
+		return dec.AddString(&t.name)
+	}
+	return nil
+}
+
+func (t *testStringToObj) NKeys() int {
+	return 3
+}
+
+func TestDecoderStringToObjectField(t *testing.T) {
+	json := `{"id":1,"blob":"` + strings.Repeat(`blob \"data\" `, 1000) + `","name":"gojay"}`
+	dec := NewDecoder(iotest.OneByteReader(strings.NewReader(json)))
+	defer dec.addToPool()
+	v := &testStringToObj{blob: &bytes.Buffer{}}
+	err := dec.Decode(v)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 1, v.id, "v.id should be 1")
+	assert.Equal(t, strings.Repeat(`blob "data" `, 1000), v.blob.String(), "v.blob should be equal to the expected value")
+	assert.Equal(t, "gojay", v.name, "v.name should be 'gojay'")
+}
+
+func TestDecoderStringToFieldHook(t *testing.T) {
+	blob := strings.Repeat("x", 3000)
+	json := `{"id":1,"blob":"` + blob + `","name":"gojay"}`
+	type field struct {
+		key        string
+		start, end int
+	}
+	var fields []field
+	dec := NewDecoder(strings.NewReader(json))
+	defer dec.addToPool()
+	dec.SetFieldHook(func(key string, start, end int) {
+		fields = append(fields, field{key, start, end})
+	})
+	v := &testStringToObj{blob: &bytes.Buffer{}}
+	err := dec.Decode(v)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, blob, v.blob.String(), "v.blob should be equal to the expected value")
+	assert.Equal(t, "gojay", v.name, "v.name should be 'gojay'")
+	assert.Equal(
+		t,
+		[]field{{"id", 6, 7}, {"blob", 15, 3017}, {"name", 25 + len(blob), 32 + len(blob)}},
+		fields,
+		"field hook should receive the keys and spans of the values",
+	)
+
+	dec = NewDecoder(strings.NewReader(json))
+	defer dec.addToPool()
+	dec.SetMaxValueBytes(1000)
+	err = dec.Decode(&testStringToObj{blob: &bytes.Buffer{}})
+	assert.IsType(t, LimitExceededError(""), err, "err should be of type LimitExceededError")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -496,3 +497,50 @@ func TestDecodeAllTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalTypeMismatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		v        interface{}
+		expected string
+	}{
+		{
+			name:     "object-got-array",
+			json:     `  [1,2]`,
+			v:        &TestObj{},
+			expected: "Cannot unmarshal, expected object got array at pos 2",
+		},
+		{
+			name:     "object-got-string",
+			json:     `"string"`,
+			v:        &TestObj{},
+			expected: "Cannot unmarshal, expected object got string at pos 0",
+		},
+		{
+			name:     "array-got-number",
+			json:     ` 12`,
+			v:        &testSliceInts{},
+			expected: "Cannot unmarshal, expected array got number at pos 1",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := Unmarshal([]byte(testCase.json), testCase.v)
+			assert.NotNil(t, err, "err should not be nil")
+			assert.IsType(t, &TypeMismatchError{}, err, "err should be of type *TypeMismatchError")
+			assert.Equal(t, testCase.expected, err.Error(), "err message should be the expected one")
+
+			dec := NewDecoder(strings.NewReader(testCase.json))
+			defer dec.addToPool()
+			err = dec.Decode(testCase.v)
+			assert.IsType(t, &TypeMismatchError{}, err, "err should be of type *TypeMismatchError")
+		})
+	}
+	// null is accepted for any kind
+	err := UnmarshalObject([]byte(`null`), &TestObj{})
+	assert.Nil(t, err, "err should be nil")
+	mismatch := UnmarshalObject([]byte(`[]`), &TestObj{}).(*TypeMismatchError)
+	assert.Equal(t, KindObject, mismatch.Expected, "expected kind should be object")
+	assert.Equal(t, KindArray, mismatch.Actual, "actual kind should be array")
+}
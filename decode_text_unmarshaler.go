@@ -0,0 +1,50 @@
+package gojay
+
+import (
+	"encoding"
+	"fmt"
+)
+
+// DecodeTextUnmarshaler reads the next JSON-encoded value from its input, a string,
+// and passes its unescaped content to the UnmarshalText method of v.
+//
+// If the JSON value is null, UnmarshalText is not called. If it is not a string, an InvalidTypeError is returned.
+// The error returned by UnmarshalText, if any, is returned as is.
+func (dec *Decoder) DecodeTextUnmarshaler(v encoding.TextUnmarshaler) error {
+	switch c := dec.nextChar(); c {
+	case 'n':
+		return dec.advance(4)
+	case '"':
+	case 0:
+		return InvalidJSONError("Invalid JSON while parsing text")
+	default:
+		err := InvalidTypeError(
+			fmt.Sprintf(
+				"Cannot unmarshall to encoding.TextUnmarshaler, wrong char '%s' found at pos %d",
+				string(c),
+				dec.cursor,
+			),
+		)
+		if skipErr := dec.skipData(); skipErr != nil {
+			return skipErr
+		}
+		return err
+	}
+	var s string
+	if err := dec.DecodeString(&s); err != nil {
+		return err
+	}
+	// s points to the decoder's buffer, the conversion copies it as UnmarshalText may retain it
+	return v.UnmarshalText([]byte(s))
+}
+
+// AddTextUnmarshaler decodes the next key, a string, to an encoding.TextUnmarshaler.
+// If next key is neither a JSON string nor null, InvalidTypeError will be returned.
+func (dec *Decoder) AddTextUnmarshaler(v encoding.TextUnmarshaler) error {
+	err := dec.DecodeTextUnmarshaler(v)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
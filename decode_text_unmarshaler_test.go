@@ -0,0 +1,61 @@
+package gojay
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTextObj struct {
+	point testTextPoint
+	ip    net.IP
+	other testTextPoint
+}
+
+func (o *testTextObj) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "point":
+		return dec.AddTextUnmarshaler(&o.point)
+	case "ip":
+		return dec.AddTextUnmarshaler(&o.ip)
+	case "other":
+		return dec.AddTextUnmarshaler(&o.other)
+	}
+	return nil
+}
+
+func (o *testTextObj) NKeys() int {
+	return 3
+}
+
+func TestDecoderTextUnmarshaler(t *testing.T) {
+	json := `{"point":"1,\"2\"","ip":"127.0.0.1","other":null}`
+	v := &testTextObj{other: testTextPoint{"a", "b"}}
+	dec := NewDecoder(iotest.OneByteReader(strings.NewReader(json)))
+	defer dec.addToPool()
+	err := dec.Decode(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, testTextPoint{"1", `"2"`}, v.point, "point should be unmarshaled from its text")
+	assert.Equal(t, "127.0.0.1", v.ip.String(), "ip should be unmarshaled from its text")
+	assert.Equal(t, testTextPoint{"a", "b"}, v.other, "null should leave the value untouched")
+
+	p := &testTextPoint{}
+	err = Unmarshal([]byte(`"3,4"`), p)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, testTextPoint{"3", "4"}, *p, "point should be unmarshaled from its text")
+}
+
+func TestDecoderTextUnmarshalerErrors(t *testing.T) {
+	err := Unmarshal([]byte(`"3"`), &testTextPoint{})
+	assert.NotNil(t, err, "Error should not be nil")
+	assert.Equal(t, "invalid point", err.Error(), "err should be the one returned by UnmarshalText")
+
+	err = UnmarshalObject([]byte(`{"point":12,"ip":"127.0.0.1"}`), &testTextObj{})
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+
+	err = Unmarshal([]byte(`"3,4`), &testTextPoint{})
+	assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+}
@@ -0,0 +1,48 @@
+package gojay
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// DecodeTimeFloatSeconds reads the next JSON-encoded value from its input, a number of seconds elapsed since epoch,
+// and stores the corresponding time in the time pointed to by v.
+//
+// The time is rounded to the nearest nanosecond. If the JSON value is null, v is left untouched.
+// If the number of nanoseconds overflows a time.Duration, an InvalidTypeError is set and v is left untouched.
+func (dec *Decoder) DecodeTimeFloatSeconds(v *time.Time, epoch time.Time) error {
+	if dec.nextChar() == 'n' {
+		dec.cursor = dec.cursor + 4
+		return nil
+	}
+	prevErr := dec.err
+	var f float64
+	if err := dec.DecodeFloat64(&f); err != nil {
+		return err
+	}
+	if dec.err != prevErr {
+		return nil
+	}
+	ns := math.Floor(f*float64(time.Second) + 0.5)
+	// NaN fails both comparisons, the upper bound is 2^63 once converted to a float
+	if !(ns >= math.MinInt64 && ns < math.MaxInt64) {
+		dec.err = InvalidTypeError(
+			fmt.Sprintf("Cannot unmarshal %g seconds to time, it overflows a time.Duration from epoch", f),
+		)
+		return nil
+	}
+	*v = epoch.Add(time.Duration(ns))
+	return nil
+}
+
+// AddTimeFloatSeconds decodes the next key, a number of seconds elapsed since epoch, to a *time.Time.
+// If next key is not a JSON number nor null, InvalidTypeError will be returned.
+func (dec *Decoder) AddTimeFloatSeconds(v *time.Time, epoch time.Time) error {
+	err := dec.DecodeTimeFloatSeconds(v, epoch)
+	if err != nil {
+		return err
+	}
+	dec.called |= 1
+	return nil
+}
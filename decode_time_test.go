@@ -0,0 +1,99 @@
+package gojay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDecodeTimeFloatSeconds struct {
+	t     time.Time
+	epoch time.Time
+}
+
+func (t *testDecodeTimeFloatSeconds) UnmarshalObject(dec *Decoder, key string) error {
+	switch key {
+	case "t":
+		return dec.AddTimeFloatSeconds(&t.t, t.epoch)
+	}
+	return nil
+}
+
+func (t *testDecodeTimeFloatSeconds) NKeys() int {
+	return 1
+}
+
+func TestDecoderTimeFloatSeconds(t *testing.T) {
+	epoch := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		json     string
+		expected time.Time
+		err      bool
+	}{
+		{
+			name:     "after-epoch",
+			json:     `{"t":90.25}`,
+			expected: epoch.Add(90*time.Second + 250*time.Millisecond),
+		},
+		{
+			name:     "sub-second",
+			json:     `{"t":0.000001}`,
+			expected: epoch.Add(time.Microsecond),
+		},
+		{
+			name:     "before-epoch",
+			json:     `{"t":-1.5}`,
+			expected: epoch.Add(-1500 * time.Millisecond),
+		},
+		{
+			name: "null",
+			json: `{"t":null}`,
+		},
+		{
+			name: "invalid-type",
+			json: `{"t":"90.25"}`,
+			err:  true,
+		},
+		{
+			name: "overflow",
+			json: `{"t":1e300}`,
+			err:  true,
+		},
+		{
+			name: "negative-overflow",
+			json: `{"t":-9.3e9}`,
+			err:  true,
+		},
+		{
+			name:     "max-duration",
+			json:     `{"t":9.2e9}`,
+			expected: epoch.Add(9200000000 * time.Second),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			v := &testDecodeTimeFloatSeconds{epoch: epoch}
+			err := UnmarshalObject([]byte(testCase.json), v)
+			if testCase.err {
+				assert.NotNil(t, err, "err should not be nil")
+				assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+				return
+			}
+			assert.Nil(t, err, "err should be nil")
+			assert.True(t, testCase.expected.Equal(v.t), "time should be the expected one")
+		})
+	}
+}
+
+func TestDecoderTimeFloatSecondsRoundTrip(t *testing.T) {
+	epoch := time.Unix(0, 0).UTC()
+	expected := time.Date(2018, 4, 1, 12, 30, 15, 123456000, time.UTC)
+	b, err := MarshalObject(&testTimeFloatSeconds{&expected, epoch})
+	assert.Nil(t, err, "err should be nil")
+	v := &testDecodeTimeFloatSeconds{epoch: epoch}
+	err = UnmarshalObject(b, v)
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, expected.Equal(v.t), "time should survive a round trip at microsecond precision")
+}
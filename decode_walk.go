@@ -0,0 +1,286 @@
+package gojay
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// SAXHandler is the interface to implement to receive the events emitted by Decoder.Walk.
+//
+// Strings passed to OnKey, OnString and OnNumber point to the decoder's buffer,
+// they must be copied to be retained after the method returns.
+// If a method returns a non nil error, the walk stops and Walk returns that error.
+type SAXHandler interface {
+	OnObjectStart() error
+	OnObjectEnd() error
+	OnArrayStart() error
+	OnArrayEnd() error
+	OnKey(key string) error
+	OnString(value string) error
+	// OnNumber receives the number as written in the JSON input to avoid any loss of precision.
+	OnNumber(value string) error
+	OnBool(value bool) error
+	OnNull() error
+}
+
+// Walk reads the next JSON value from its input and calls the methods of h
+// as its structure is traversed, without building any Go value.
+//
+// Contrary to decoding methods, Walk is strict about the structure of its input:
+// commas and colons are required, trailing commas are rejected.
+// Malformed input stops the walk and returns an InvalidJSONError.
+func (dec *Decoder) Walk(h SAXHandler) error {
+	return dec.walkValue(h)
+}
+
+func (dec *Decoder) walkValue(h SAXHandler) error {
+	switch c := dec.walkSpace(); c {
+	case '{':
+		return dec.walkObject(h)
+	case '[':
+		return dec.walkArray(h)
+	case '"':
+		s, err := dec.walkString()
+		if err != nil {
+			return err
+		}
+		return h.OnString(s)
+	case 't':
+		if err := dec.walkLiteral("true"); err != nil {
+			return err
+		}
+		return h.OnBool(true)
+	case 'f':
+		if err := dec.walkLiteral("false"); err != nil {
+			return err
+		}
+		return h.OnBool(false)
+	case 'n':
+		if err := dec.walkLiteral("null"); err != nil {
+			return err
+		}
+		return h.OnNull()
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return dec.walkNumber(h)
+	case 0:
+		return InvalidJSONError("Invalid JSON, unexpected end of input")
+	default:
+		return dec.walkError(c)
+	}
+}
+
+func (dec *Decoder) walkObject(h SAXHandler) error {
+	dec.cursor = dec.cursor + 1
+	if err := h.OnObjectStart(); err != nil {
+		return err
+	}
+	if dec.walkSpace() == '}' {
+		dec.cursor = dec.cursor + 1
+		return h.OnObjectEnd()
+	}
+	for {
+		if c := dec.walkSpace(); c != '"' {
+			return dec.walkError(c)
+		}
+		key, err := dec.walkString()
+		if err != nil {
+			return err
+		}
+		if c := dec.walkSpace(); c != ':' {
+			return dec.walkError(c)
+		}
+		dec.cursor = dec.cursor + 1
+		if err := h.OnKey(key); err != nil {
+			return err
+		}
+		if err := dec.walkValue(h); err != nil {
+			return err
+		}
+		switch c := dec.walkSpace(); c {
+		case ',':
+			dec.cursor = dec.cursor + 1
+		case '}':
+			dec.cursor = dec.cursor + 1
+			return h.OnObjectEnd()
+		default:
+			return dec.walkError(c)
+		}
+	}
+}
+
+func (dec *Decoder) walkArray(h SAXHandler) error {
+	dec.cursor = dec.cursor + 1
+	if err := h.OnArrayStart(); err != nil {
+		return err
+	}
+	if dec.walkSpace() == ']' {
+		dec.cursor = dec.cursor + 1
+		return h.OnArrayEnd()
+	}
+	for {
+		if err := dec.walkValue(h); err != nil {
+			return err
+		}
+		switch c := dec.walkSpace(); c {
+		case ',':
+			dec.cursor = dec.cursor + 1
+		case ']':
+			dec.cursor = dec.cursor + 1
+			return h.OnArrayEnd()
+		default:
+			return dec.walkError(c)
+		}
+	}
+}
+
+// walkString reads the string starting at the cursor, its opening quote included.
+func (dec *Decoder) walkString() (string, error) {
+	dec.cursor = dec.cursor + 1
+	start, end, err := dec.getString()
+	if err != nil {
+		return "", err
+	}
+	// we do minus one to remove the last quote
+	d := dec.data[start : end-1]
+	return *(*string)(unsafe.Pointer(&d)), nil
+}
+
+func (dec *Decoder) walkNumber(h SAXHandler) error {
+	start := dec.cursor
+	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
+		switch dec.data[dec.cursor] {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', '-', '+', '.', 'e', 'E':
+			continue
+		}
+		break
+	}
+	d := dec.data[start:dec.cursor]
+	s := *(*string)(unsafe.Pointer(&d))
+	if !isValidNumber(s) {
+		return InvalidJSONError(fmt.Sprintf("Invalid JSON, invalid number '%s' found at pos %d", s, start))
+	}
+	return h.OnNumber(s)
+}
+
+func (dec *Decoder) walkLiteral(lit string) error {
+	start := dec.cursor
+	for i := 0; i < len(lit); i++ {
+		if !(dec.cursor < dec.length || dec.read()) || dec.data[dec.cursor] != lit[i] {
+			return InvalidJSONError(fmt.Sprintf("Invalid JSON, expected '%s' at pos %d", lit, start))
+		}
+		dec.cursor = dec.cursor + 1
+	}
+	return nil
+}
+
+// walkSpace skips whitespaces and returns the next char, or 0 if the input is exhausted.
+// Contrary to nextChar, commas are not skipped.
+func (dec *Decoder) walkSpace() byte {
+	for ; dec.cursor < dec.length || dec.read(); dec.cursor++ {
+		switch dec.data[dec.cursor] {
+		case ' ', '\n', '\t', '\r':
+			continue
+		}
+		return dec.data[dec.cursor]
+	}
+	return 0
+}
+
+// appendMinified reads the next JSON value from its input as Walk does and appends it to dst
+// without its insignificant whitespace. Strings are skipped without being unescaped and copied as is.
+func (dec *Decoder) appendMinified(dst []byte) ([]byte, error) {
+	switch c := dec.walkSpace(); c {
+	case '{', '[':
+		end := byte('}')
+		if c == '[' {
+			end = ']'
+		}
+		dst = append(dst, c)
+		dec.cursor = dec.cursor + 1
+		if dec.walkSpace() == end {
+			dec.cursor = dec.cursor + 1
+			return append(dst, end), nil
+		}
+		for {
+			var err error
+			if c == '{' {
+				if k := dec.walkSpace(); k != '"' {
+					return dst, dec.walkError(k)
+				}
+				if dst, err = dec.appendMinified(dst); err != nil {
+					return dst, err
+				}
+				if k := dec.walkSpace(); k != ':' {
+					return dst, dec.walkError(k)
+				}
+				dst = append(dst, ':')
+				dec.cursor = dec.cursor + 1
+			}
+			if dst, err = dec.appendMinified(dst); err != nil {
+				return dst, err
+			}
+			switch k := dec.walkSpace(); k {
+			case ',':
+				dst = append(dst, ',')
+				dec.cursor = dec.cursor + 1
+			case end:
+				dec.cursor = dec.cursor + 1
+				return append(dst, end), nil
+			default:
+				return dst, dec.walkError(k)
+			}
+		}
+	case '"':
+		start := dec.cursor
+		dec.cursor = dec.cursor + 1
+		if err := dec.skipString(); err != nil {
+			return dst, err
+		}
+		return append(dst, dec.data[start:dec.cursor]...), nil
+	case 't', 'f', 'n':
+		lit := "null"
+		if c == 't' {
+			lit = "true"
+		} else if c == 'f' {
+			lit = "false"
+		}
+		if err := dec.walkLiteral(lit); err != nil {
+			return dst, err
+		}
+		return append(dst, lit...), nil
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		start := dec.cursor
+		if err := dec.walkNumber(discardHandler{}); err != nil {
+			return dst, err
+		}
+		return append(dst, dec.data[start:dec.cursor]...), nil
+	default:
+		return dst, dec.walkError(c)
+	}
+}
+
+// discardHandler is a SAXHandler ignoring all the events
+type discardHandler struct{}
+
+func (discardHandler) OnObjectStart() error    { return nil }
+func (discardHandler) OnObjectEnd() error      { return nil }
+func (discardHandler) OnArrayStart() error     { return nil }
+func (discardHandler) OnArrayEnd() error       { return nil }
+func (discardHandler) OnKey(key string) error  { return nil }
+func (discardHandler) OnString(s string) error { return nil }
+func (discardHandler) OnNumber(s string) error { return nil }
+func (discardHandler) OnBool(value bool) error { return nil }
+func (discardHandler) OnNull() error           { return nil }
+
+func (dec *Decoder) walkError(c byte) error {
+	if c == 0 {
+		return InvalidJSONError("Invalid JSON, unexpected end of input")
+	}
+	return InvalidJSONError(
+		fmt.Sprintf(
+			"Invalid JSON, wrong char '%s' found at pos %d",
+			string(c),
+			dec.cursor,
+		),
+	)
+}
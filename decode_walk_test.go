@@ -0,0 +1,111 @@
+package gojay
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSAXHandler struct {
+	events []string
+	stopAt string
+}
+
+func (h *testSAXHandler) event(e string) error {
+	h.events = append(h.events, e)
+	if h.stopAt != "" && e == h.stopAt {
+		return errors.New("stop")
+	}
+	return nil
+}
+
+func (h *testSAXHandler) OnObjectStart() error { return h.event("{") }
+func (h *testSAXHandler) OnObjectEnd() error   { return h.event("}") }
+func (h *testSAXHandler) OnArrayStart() error  { return h.event("[") }
+func (h *testSAXHandler) OnArrayEnd() error    { return h.event("]") }
+func (h *testSAXHandler) OnKey(key string) error {
+	return h.event("key:" + key)
+}
+func (h *testSAXHandler) OnString(value string) error {
+	return h.event("string:" + value)
+}
+func (h *testSAXHandler) OnNumber(value string) error {
+	return h.event("number:" + value)
+}
+func (h *testSAXHandler) OnBool(value bool) error {
+	return h.event("bool:" + strconv.FormatBool(value))
+}
+func (h *testSAXHandler) OnNull() error { return h.event("null") }
+
+func TestDecoderWalk(t *testing.T) {
+	json := `{"a": "str\"ing", "b" : [1, -2.5e3, true, false, null, {}], "c": {"d": []}}`
+	expected := []string{
+		"{",
+		"key:a", `string:str"ing`,
+		"key:b", "[", "number:1", "number:-2.5e3", "bool:true", "bool:false", "null", "{", "}", "]",
+		"key:c", "{", "key:d", "[", "]", "}",
+		"}",
+	}
+	h := &testSAXHandler{}
+	dec := NewDecoder(strings.NewReader(json))
+	defer dec.addToPool()
+	err := dec.Walk(h)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, expected, h.events, "events should be equal to the expected ones")
+
+	// reading one byte at a time
+	h = &testSAXHandler{}
+	dec = NewDecoder(iotest.OneByteReader(strings.NewReader(json)))
+	defer dec.addToPool()
+	err = dec.Walk(h)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, expected, h.events, "events should be equal to the expected ones")
+}
+
+func TestDecoderWalkErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		json string
+	}{
+		{name: "missing-colon", json: `{"a" 1}`},
+		{name: "missing-comma-object", json: `{"a":1 "b":2}`},
+		{name: "missing-comma-array", json: `[1 2]`},
+		{name: "trailing-comma-object", json: `{"a":1,}`},
+		{name: "trailing-comma-array", json: `[1,]`},
+		{name: "unterminated-object", json: `{"a":1`},
+		{name: "unterminated-array", json: `[1,[2]`},
+		{name: "mismatched-closing", json: `[1}`},
+		{name: "invalid-literal", json: `[tru]`},
+		{name: "invalid-number", json: `[1-2]`},
+		{name: "number-trailing-dot", json: `[1.]`},
+		{name: "number-leading-zero", json: `[01]`},
+		{name: "number-empty-exponent", json: `[1e]`},
+		{name: "number-leading-dot", json: `[-.5]`},
+		{name: "non-string-key", json: `{1:2}`},
+		{name: "empty", json: ``},
+		{name: "unterminated-string", json: `["abc`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(testCase.json))
+			defer dec.addToPool()
+			err := dec.Walk(&testSAXHandler{})
+			assert.NotNil(t, err, "err should not be nil")
+			assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+		})
+	}
+}
+
+func TestDecoderWalkHandlerError(t *testing.T) {
+	h := &testSAXHandler{stopAt: "key:b"}
+	dec := NewDecoder(strings.NewReader(`{"a":1,"b":2,"c":3}`))
+	defer dec.addToPool()
+	err := dec.Walk(h)
+	assert.NotNil(t, err, "err should not be nil")
+	assert.Equal(t, "stop", err.Error(), "err should be the one returned by the handler")
+	assert.Equal(t, []string{"{", "key:a", "number:1", "key:b"}, h.events, "walk should stop at the handler error")
+}
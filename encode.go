@@ -1,5 +1,14 @@
 package gojay
 
+import (
+	"encoding"
+	"encoding/json"
+	"io"
+	"math/big"
+	"reflect"
+	"unsafe"
+)
+
 // MarshalObject returns the JSON encoding of v.
 //
 // It takes a struct implementing Marshaler to a JSON slice of byte
@@ -23,13 +32,36 @@ package gojay
 // 		fmt.Println(b) // {"id":123456}
 //	}
 func MarshalObject(v MarshalerObject) ([]byte, error) {
+	return MarshalObjectSize(v, poolConfig.EncoderBufferSize)
+}
+
+// MarshalObjectString returns the JSON encoding of v as a string, as MarshalObject does.
+//
+// The string is built on the buffer v was encoded to, without copying it: the buffer is owned by the string
+// and not given back to the Encoder's pool. The whole capacity of the buffer is kept alive with the string,
+// see MarshalObjectSize to encode small values in small buffers.
+func MarshalObjectString(v MarshalerObject) (string, error) {
+	b, err := MarshalObject(v)
+	if err != nil {
+		return "", err
+	}
+	return *(*string)(unsafe.Pointer(&b)), nil
+}
+
+// MarshalObjectSize returns the JSON encoding of v as MarshalObject does,
+// starting with a buffer of hint bytes instead of the EncoderBufferSize of the PoolConfig.
+//
+// A hint close to the size of the encoding, such as the size of a previous encoding of a similar value,
+// saves the successive reallocations of the buffer as it grows. If hint is not positive, the default size is used.
+func MarshalObjectSize(v MarshalerObject, hint int) ([]byte, error) {
+	if hint <= 0 {
+		hint = poolConfig.EncoderBufferSize
+	}
 	enc := NewEncoder()
-	enc.grow(200)
-	enc.writeByte('{')
-	v.MarshalObject(enc)
-	enc.writeByte('}')
+	enc.grow(hint)
+	enc.writeObject(v)
 	defer enc.addToPool()
-	return enc.buf, nil
+	return enc.encoded()
 }
 
 // MarshalArray returns the JSON encoding of v.
@@ -54,13 +86,21 @@ func MarshalObject(v MarshalerObject) ([]byte, error) {
 //		fmt.Println(b) // [{"id":123456},{"id":7890}]
 //	}
 func MarshalArray(v MarshalerArray) ([]byte, error) {
+	return MarshalArraySize(v, poolConfig.EncoderBufferSize)
+}
+
+// MarshalArraySize returns the JSON encoding of v as MarshalArray does,
+// starting with a buffer of hint bytes instead of the EncoderBufferSize of the PoolConfig.
+// If hint is not positive, the default size is used.
+func MarshalArraySize(v MarshalerArray, hint int) ([]byte, error) {
+	if hint <= 0 {
+		hint = poolConfig.EncoderBufferSize
+	}
 	enc := NewEncoder()
-	enc.grow(200)
-	enc.writeByte('[')
-	v.(MarshalerArray).MarshalArray(enc)
-	enc.writeByte(']')
+	enc.grow(hint)
+	enc.writeArray(v)
 	defer enc.addToPool()
-	return enc.buf, nil
+	return enc.encoded()
 }
 
 // Marshal returns the JSON encoding of v.
@@ -72,9 +112,12 @@ func MarshalArray(v MarshalerArray) ([]byte, error) {
 //
 // If v implements Marshaler or Marshaler interface
 // it will call the corresponding methods.
+// If v only implements json.Marshaler, the output of its MarshalJSON method is embedded,
+// if it only implements encoding.TextMarshaler, its text is encoded as a string.
 //
-// If a struct, slice, or array is passed and does not implement these interfaces
-// it will return a a non nil InvalidTypeError error.
+// If a struct, slice, map or any other value is passed and does not implement these interfaces
+// it is encoded by reflection, following the json tags of struct fields as encoding/json does.
+// A non nil InvalidTypeError is returned for the values reflection cannot encode, such as channels.
 // Example with an Marshaler:
 //	type TestStruct struct {
 //		id int
@@ -99,20 +142,29 @@ func Marshal(v interface{}) ([]byte, error) {
 	switch vt := v.(type) {
 	case MarshalerObject:
 		enc := NewEncoder()
-		enc.writeByte('{')
-		vt.MarshalObject(enc)
-		enc.writeByte('}')
-		b = enc.buf
+		enc.writeObject(vt)
 		defer enc.addToPool()
-		return b, nil
+		return enc.encoded()
 	case MarshalerArray:
 		enc := NewEncoder()
-		enc.writeByte('[')
-		vt.MarshalArray(enc)
-		enc.writeByte(']')
-		b = enc.buf
+		enc.writeArray(vt)
+		defer enc.addToPool()
+		return enc.encoded()
+	case MarshalerMap:
+		enc := NewEncoder()
+		enc.AddMap(vt)
+		defer enc.addToPool()
+		return enc.encoded()
+	case map[string]interface{}:
+		enc := NewEncoder()
+		enc.AddMap(interfaceMap(vt))
+		defer enc.addToPool()
+		return enc.encoded()
+	case []interface{}:
+		enc := NewEncoder()
+		enc.AddArray(interfaceSlice(vt))
 		defer enc.addToPool()
-		return b, nil
+		return enc.encoded()
 	case string:
 		enc := NewEncoder()
 		b, err = enc.encodeString(vt)
@@ -142,21 +194,29 @@ func Marshal(v interface{}) ([]byte, error) {
 		enc := NewEncoder()
 		defer enc.addToPool()
 		return enc.encodeInt(int64(vt))
+	case uint:
+		enc := NewEncoder()
+		defer enc.addToPool()
+		return enc.encodeUint(uint64(vt))
+	case uintptr:
+		enc := NewEncoder()
+		defer enc.addToPool()
+		return enc.encodeUint(uint64(vt))
 	case uint64:
 		enc := NewEncoder()
 		defer enc.addToPool()
-		return enc.encodeInt(int64(vt))
+		return enc.encodeUint(vt)
 	case uint32:
 		enc := NewEncoder()
 		defer enc.addToPool()
-		return enc.encodeInt(int64(vt))
+		return enc.encodeUint(uint64(vt))
 	case uint16:
 		enc := NewEncoder()
 		defer enc.addToPool()
-		return enc.encodeInt(int64(vt))
+		return enc.encodeUint(uint64(vt))
 	case uint8:
 		enc := NewEncoder()
-		b, err = enc.encodeInt(int64(vt))
+		b, err = enc.encodeUint(uint64(vt))
 		defer enc.addToPool()
 	case float64:
 		enc := NewEncoder()
@@ -166,6 +226,42 @@ func Marshal(v interface{}) ([]byte, error) {
 		enc := NewEncoder()
 		defer enc.addToPool()
 		return enc.encodeFloat(float64(vt))
+	case json.Number:
+		enc := NewEncoder()
+		defer enc.addToPool()
+		err = enc.AddNumber(vt)
+		b = enc.buf
+	case *big.Int:
+		enc := NewEncoder()
+		defer enc.addToPool()
+		err = enc.AddBigInt(vt)
+		b = enc.buf
+	case *big.Float:
+		enc := NewEncoder()
+		defer enc.addToPool()
+		err = enc.AddBigFloat(vt)
+		b = enc.buf
+	case json.Marshaler:
+		enc := NewEncoder()
+		defer enc.addToPool()
+		err = enc.AddJSONMarshaler(vt)
+		b = enc.buf
+	case encoding.TextMarshaler:
+		enc := NewEncoder()
+		defer enc.addToPool()
+		err = enc.AddTextMarshaler(vt)
+		b = enc.buf
+	default:
+		if v == nil {
+			break
+		}
+		// no Marshaler interface implemented, encoding by reflection
+		enc := NewEncoder()
+		defer enc.addToPool()
+		if err = enc.addReflect(reflect.ValueOf(v)); err != nil {
+			return nil, err
+		}
+		b = enc.buf
 	}
 	return b, err
 }
@@ -182,15 +278,173 @@ type MarshalerArray interface {
 	MarshalArray(enc *Encoder)
 }
 
+// NilMarshalerArray is the interface a MarshalerArray can implement so that a nil value
+// is encoded as null, the same way as a MarshalerObject whose IsNil method returns true.
+// The other MarshalerArray values are only encoded as null if the interface value itself is nil.
+type NilMarshalerArray interface {
+	MarshalerArray
+	IsNil() bool
+}
+
+// MarshalerStream is the interface of the values sent to a StreamEncoder,
+// each one is encoded as an object on its own line.
+type MarshalerStream interface {
+	MarshalerObject
+}
+
+// MarshalerArrayRange is the interface to implement for a slice or an array whose elements
+// can be encoded by ranges, so that MarshalArrayParallel can encode it on several goroutines.
+// MarshalArrayRange must add the elements from index from included to index to excluded, as MarshalArray adds them all.
+type MarshalerArrayRange interface {
+	MarshalerArray
+	Len() int
+	MarshalArrayRange(enc *Encoder, from, to int)
+}
+
 // An Encoder writes JSON values to an output stream.
 type Encoder struct {
-	buf []byte
+	buf            []byte
+	minifyEmbedded bool
+	strCache       *stringCache
+	schemaVersion  int
+	sortMapKeys    bool
+	pageKeys       *PaginationKeys
+	envelope       map[string]interface{}
+	escapeTable    *[256]bool
+	stripNulls     bool
+	keyFilter      func(key string) bool
+	fieldHook      func(key string, write func())
+	// hookDepth is the depth plus one of the object the field hook is running for, 0 if it is not running
+	hookDepth      int
+	strTransform   func(string) string
+	timeLayout     string
+	timeFormat     TimeFormat
+	durationFormat DurationFormat
+	// floatFormat and floatPrecision are set by SetFloatFormat and SetFloatPrecision,
+	// floats are written in the shortest 'f' format while they are not
+	floatFormat       byte
+	floatPrecision    int
+	hasFloatPrecision bool
+	nanPolicy         NaNPolicy
+	// compact is the number of nested compact subtrees being written,
+	// output is not indented while it is not zero
+	compact int
+	// hasValue reports whether a value has been written in the current object or array
+	hasValue bool
+	// depth is the number of objects and arrays currently open
+	depth int
+	w     io.Writer
+	flush arrayFlush
+	// trailingNewline is set by SetTrailingNewline, values written by stream are then followed by a new line
+	trailingNewline bool
+	// streaming is set while a value is written by EncodeObject, EncodeArray or Encode,
+	// the buffer is then flushed to w once it holds streamFlushSize bytes, unless pinned is not zero
+	streaming bool
+	pinned    int
+	streamErr error
+	err       error
+	// indented reports whether SetIndent was called
+	indented     bool
+	indentPrefix string
+	indent       string
+	// written is the number of bytes flushed to w
+	written int
+	stats   *Stats
+	// sortKeys is set by SetSortKeys, sortFrames then holds the objects and arrays currently open
+	sortKeys   bool
+	sortFrames []sortFrame
+	// sortMap is set while a map is opened with SetSortMapKeys set, the fields of its frame are then sorted
+	sortMap   bool
+	canonical bool
+	// checksums are the checksum fields added by WithChecksum, written when their object is closed
+	checksums []pendingChecksum
+}
+
+// Bytes returns the bytes encoded so far by the Encoder.
+//
+// The slice is only valid until the Encoder is used again.
+func (enc *Encoder) Bytes() []byte {
+	return enc.buf
+}
+
+// Flush writes the bytes encoded so far to the Encoder's io.Writer and empties its buffer.
+// The Encoder can be used to keep encoding the current value afterwards, even in the middle of an object or array.
+//
+// If the Encoder was not created with NewEncoderWriter, a NoWriterError is returned.
+func (enc *Encoder) Flush() error {
+	if enc.w == nil {
+		return NoWriterError("No writer given to Encoder")
+	}
+	if _, err := enc.w.Write(enc.buf); err != nil {
+		return err
+	}
+	enc.written += len(enc.buf)
+	if m := metrics(); m != nil {
+		m.Encoded(len(enc.buf))
+	}
+	enc.buf = enc.buf[:0]
+	return nil
+}
+
+// SetSchemaVersion sets the schema version v on the Encoder.
+// It can be retrieved with SchemaVersion inside MarshalObject or MarshalArray implementations
+// to version-gate the fields to encode.
+func (enc *Encoder) SetSchemaVersion(v int) {
+	enc.schemaVersion = v
+}
+
+// SchemaVersion returns the schema version set on the Encoder, 0 if none was set.
+func (enc *Encoder) SchemaVersion() int {
+	return enc.schemaVersion
+}
+
+// writeSep writes a comma if a value has already been written in the current object or array.
+//
+// Whether a separator is pending is tracked by the Encoder rather than read from the buffer,
+// so that it remains correct once the buffer has been flushed.
+func (enc *Encoder) writeSep() {
+	if enc.flush.every > 0 && enc.depth == enc.flush.depth {
+		enc.flushElement()
+	}
+	if enc.hasValue {
+		enc.writeByte(',')
+	}
+	enc.hasValue = true
+	if enc.sorting() {
+		enc.recordField()
+	}
+	if enc.indented && enc.compact == 0 && enc.depth > 0 {
+		enc.writeIndent(enc.depth)
+	}
+}
+
+// writeOpen writes c, the opening char of an object or an array.
+func (enc *Encoder) writeOpen(c byte) {
+	enc.writeByte(c)
+	enc.enter()
 }
 
-func (enc *Encoder) getPreviousRune() (byte, bool) {
-	last := len(enc.buf) - 1
-	if last < 0 {
-		return 0, false
+// enter records that an object or an array has just been opened.
+func (enc *Encoder) enter() {
+	enc.depth++
+	enc.hasValue = false
+	if enc.sorting() || enc.sortMap {
+		enc.pushSortFrame()
+	}
+}
+
+// writeClose writes c, the closing char of an object or an array.
+func (enc *Encoder) writeClose(c byte) {
+	enc.depth--
+	if enc.sorting() {
+		enc.popSortFrame()
+	}
+	if n := len(enc.checksums); n > 0 && enc.checksums[n-1].depth > enc.depth {
+		enc.writeChecksum(c)
+	}
+	if enc.indented && enc.compact == 0 && enc.hasValue {
+		enc.writeIndent(enc.depth)
 	}
-	return enc.buf[last], true
+	enc.writeByte(c)
+	enc.hasValue = true
 }
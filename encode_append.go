@@ -0,0 +1,124 @@
+package gojay
+
+import (
+	"encoding"
+	"encoding/json"
+	"math/big"
+	"reflect"
+)
+
+// MarshalObjectAppend appends the JSON encoding of v to dst and returns the extended buffer.
+//
+// Unlike MarshalObject, the bytes are written to the caller's slice, which is grown as needed,
+// so a buffer can be reused from one call to the next:
+//
+//	buf := make([]byte, 0, 512)
+//	for _, v := range values {
+//		buf, _ = gojay.MarshalObjectAppend(buf[:0], v)
+//		w.Write(buf)
+//	}
+func MarshalObjectAppend(dst []byte, v MarshalerObject) ([]byte, error) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.buf = dst
+	enc.writeObject(v)
+	if enc.err != nil {
+		return dst, enc.err
+	}
+	return enc.buf, nil
+}
+
+// MarshalArrayAppend appends the JSON encoding of v to dst and returns the extended buffer.
+//
+// Unlike MarshalArray, the bytes are written to the caller's slice, which is grown as needed.
+func MarshalArrayAppend(dst []byte, v MarshalerArray) ([]byte, error) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.buf = dst
+	enc.writeArray(v)
+	if enc.err != nil {
+		return dst, enc.err
+	}
+	return enc.buf, nil
+}
+
+// MarshalAppend appends the JSON encoding of v to dst and returns the extended buffer.
+//
+// It accepts the same types as Marshal. If v cannot be encoded, dst is returned unchanged with a non nil error.
+func MarshalAppend(dst []byte, v interface{}) ([]byte, error) {
+	switch vt := v.(type) {
+	case MarshalerObject:
+		return MarshalObjectAppend(dst, vt)
+	case MarshalerArray:
+		return MarshalArrayAppend(dst, vt)
+	}
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.buf = dst
+	if err := enc.encodeValue(v); err != nil {
+		return dst, err
+	}
+	return enc.buf, nil
+}
+
+// encodeValue writes v, a value of one of the non Marshaler types accepted by Marshal,
+// falling back to reflection for the types without a dedicated Add method.
+func (enc *Encoder) encodeValue(v interface{}) error {
+	var err error
+	switch vt := v.(type) {
+	case MarshalerMap:
+		err = enc.AddMap(vt)
+	case map[string]interface{}:
+		err = enc.AddMap(interfaceMap(vt))
+	case []interface{}:
+		err = enc.AddArray(interfaceSlice(vt))
+	case string:
+		_, err = enc.encodeString(vt)
+	case bool:
+		err = enc.AddBool(vt)
+	case int:
+		_, err = enc.encodeInt(int64(vt))
+	case int64:
+		_, err = enc.encodeInt(vt)
+	case int32:
+		_, err = enc.encodeInt(int64(vt))
+	case int16:
+		_, err = enc.encodeInt(int64(vt))
+	case int8:
+		_, err = enc.encodeInt(int64(vt))
+	case uint:
+		_, err = enc.encodeUint(uint64(vt))
+	case uintptr:
+		_, err = enc.encodeUint(uint64(vt))
+	case uint64:
+		_, err = enc.encodeUint(vt)
+	case uint32:
+		_, err = enc.encodeUint(uint64(vt))
+	case uint16:
+		_, err = enc.encodeUint(uint64(vt))
+	case uint8:
+		_, err = enc.encodeUint(uint64(vt))
+	case float64:
+		_, err = enc.encodeFloat(vt)
+	case float32:
+		_, err = enc.encodeFloat(float64(vt))
+	case json.Number:
+		err = enc.AddNumber(vt)
+	case *big.Int:
+		err = enc.AddBigInt(vt)
+	case *big.Float:
+		err = enc.AddBigFloat(vt)
+	case json.Marshaler:
+		err = enc.AddJSONMarshaler(vt)
+	case encoding.TextMarshaler:
+		err = enc.AddTextMarshaler(vt)
+	case nil:
+		err = InvalidTypeError("Unknown type to Marshal")
+	default:
+		err = enc.addReflect(reflect.ValueOf(v))
+	}
+	if err == nil {
+		err = enc.err
+	}
+	return err
+}
@@ -0,0 +1,68 @@
+package gojay
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderMarshalObjectAppend(t *testing.T) {
+	dst := make([]byte, 0, 64)
+	dst = append(dst, `prefix `...)
+	r, err := MarshalObjectAppend(dst, &testObject{testInt: 1, testStr: "a"})
+	assert.Nil(t, err, "Error should be nil")
+	expected, _ := MarshalObject(&testObject{testInt: 1, testStr: "a"})
+	assert.Equal(t, `prefix `+string(expected), string(r), "Result of marshalling is different as the one expected")
+	assert.Equal(t, &dst[:1][0], &r[0], "dst should be reused when large enough")
+
+	// reusing the buffer does not alter a previous result once copied
+	first := string(r)
+	r, err = MarshalObjectAppend(r[:0], EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddIntKey("b", 2)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"b":2}`, string(r), "Result of marshalling is different as the one expected")
+	assert.NotEqual(t, first, string(r), "results should differ")
+}
+
+func TestEncoderMarshalArrayAppend(t *testing.T) {
+	r, err := MarshalArrayAppend([]byte(`[1],`), EncodeArrayFunc(func(enc *Encoder) {
+		enc.AddInt(2)
+		enc.AddString("3")
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `[1],[2,"3"]`, string(r), "Result of marshalling is different as the one expected")
+}
+
+func TestEncoderMarshalAppend(t *testing.T) {
+	testCases := []struct {
+		name     string
+		v        interface{}
+		expected string
+	}{
+		{name: "string", v: `a"b`, expected: `x"a\"b"`},
+		{name: "bool", v: true, expected: `xtrue`},
+		{name: "int", v: -12, expected: `x-12`},
+		{name: "uint8", v: uint8(255), expected: `x255`},
+		{name: "float", v: 1.5, expected: `x1.5`},
+		{name: "number", v: json.Number("1e3"), expected: `x1e3`},
+		{name: "object", v: EncodeObjectFunc(func(enc *Encoder) { enc.AddIntKey("a", 1) }), expected: `x{"a":1}`},
+		{name: "array", v: EncodeArrayFunc(func(enc *Encoder) { enc.AddInt(1) }), expected: `x[1]`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r, err := MarshalAppend([]byte(`x`), testCase.v)
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(r), "Result of marshalling is different as the one expected")
+		})
+	}
+
+	dst := []byte(`x`)
+	r, err := MarshalAppend(dst, make(chan int))
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	assert.Equal(t, `x`, string(r), "dst should be returned unchanged")
+	r, err = MarshalAppend(dst, json.Number("01"))
+	assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+	assert.Equal(t, `x`, string(r), "dst should be returned unchanged")
+}
@@ -1,30 +1,205 @@
 package gojay
 
+import "strconv"
+
 // AddArray adds an array or slice to be encoded, must be used inside a slice or array encoding (does not encode a key)
-// value must implement Marshaler
+// value must implement Marshaler, if value is nil, null is added as with AddNull, see NilMarshalerArray.
 func (enc *Encoder) AddArray(value MarshalerArray) error {
-	r, ok := enc.getPreviousRune()
-	if ok && r != '[' {
-		enc.writeByte(',')
+	if isNilArray(value) {
+		return enc.AddNull()
+	}
+	if enc.err != nil {
+		return enc.err
 	}
-	enc.writeByte('[')
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeOpen('[')
 	value.MarshalArray(enc)
-	enc.writeByte(']')
-	return nil
+	enc.writeClose(']')
+	enc.record(KindArray, start)
+	return enc.err
+}
+
+// isNilArray reports whether v is nil, either as an interface value or as told by its IsNil method, see NilMarshalerArray.
+func isNilArray(v MarshalerArray) bool {
+	if v == nil {
+		return true
+	}
+	n, ok := v.(NilMarshalerArray)
+	return ok && n.IsNil()
+}
+
+// writeArray writes v as an array, or null if v is nil, and returns the Kind written.
+func (enc *Encoder) writeArray(v MarshalerArray) Kind {
+	if isNilArray(v) {
+		enc.writeString("null")
+		return KindNull
+	}
+	enc.writeOpen('[')
+	v.MarshalArray(enc)
+	enc.writeClose(']')
+	return KindArray
+}
+
+// AddArrayFlushing adds an array or slice to be encoded as AddArray does,
+// flushing the Encoder to its io.Writer every n elements so that the buffer memory stays bounded
+// whatever the number of elements.
+//
+// The buffer is flushed before writing the element following each n-th element.
+// If the Encoder was not created with NewEncoderWriter, a NoWriterError is returned.
+func (enc *Encoder) AddArrayFlushing(value MarshalerArray, n int) error {
+	return enc.addArrayFlushing(n, func() error {
+		return enc.AddArray(value)
+	})
 }
 
 // AddArrayKey adds an array or slice to be encoded, must be used inside an object as it will encode a key
-// value must implement Marshaler
+// value must implement Marshaler, if value is nil, the key is added with null as with AddNullKey, see NilMarshalerArray.
 func (enc *Encoder) AddArrayKey(key string, value MarshalerArray) error {
-	// grow to avoid allocs (length of key/value + quotes)
-	r, ok := enc.getPreviousRune()
-	if ok && r != '[' && r != '{' {
-		enc.writeByte(',')
+	if enc.skipKey(key) {
+		return nil
 	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddArrayKey(key, value) })
+	}
+	if isNilArray(value) {
+		return enc.AddNullKey(key)
+	}
+	if enc.err != nil {
+		return enc.err
+	}
+	start := enc.offset()
+	enc.writeSep()
 	enc.writeByte('"')
-	enc.writeString(key)
-	enc.write(objKeyArr)
+	enc.writeKey(key)
+	enc.writeObjKey(objKeyArr)
+	enc.enter()
 	value.MarshalArray(enc)
-	enc.writeByte(']')
+	enc.writeClose(']')
+	enc.record(KindArray, start)
+	return enc.err
+}
+
+// AddArrayKeyOmitEmpty adds an array or slice to be encoded, must be used inside an object as it will encode a key
+// If value is nil or adds no element, nothing is written, not even the key.
+func (enc *Encoder) AddArrayKeyOmitEmpty(key string, value MarshalerArray) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddArrayKeyOmitEmpty(key, value) })
+	}
+	if isNilArray(value) {
+		return nil
+	}
+	if enc.err != nil {
+		return enc.err
+	}
+	start := enc.offset()
+	mark, hasValue := len(enc.buf), enc.hasValue
+	// the key is removed from the buffer if the array is empty, it must not be flushed meanwhile
+	enc.pinned++
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKeyArr)
+	enc.enter()
+	value.MarshalArray(enc)
+	empty := !enc.hasValue
+	enc.writeClose(']')
+	enc.pinned--
+	if empty {
+		enc.truncate(mark, hasValue)
+		return enc.err
+	}
+	enc.record(KindArray, start)
+	return enc.err
+}
+
+// AddArrayKeyFlushing adds an array or slice to be encoded as AddArrayKey does,
+// flushing the Encoder to its io.Writer every n elements so that the buffer memory stays bounded
+// whatever the number of elements.
+//
+// The buffer is flushed before writing the element following each n-th element.
+// If the Encoder was not created with NewEncoderWriter, a NoWriterError is returned.
+func (enc *Encoder) AddArrayKeyFlushing(key string, value MarshalerArray, n int) error {
+	return enc.addArrayFlushing(n, func() error {
+		return enc.AddArrayKey(key, value)
+	})
+}
+
+// arrayFlush is the state of an array flushed every n elements
+type arrayFlush struct {
+	every int
+	depth int
+	count int
+	err   error
+}
+
+func (enc *Encoder) addArrayFlushing(n int, add func() error) error {
+	if enc.w == nil {
+		return NoWriterError("No writer given to Encoder")
+	}
+	prev := enc.flush
+	enc.flush = arrayFlush{every: n, depth: enc.depth + 1}
+	err := add()
+	if err == nil {
+		err = enc.flush.err
+	}
+	enc.flush = prev
+	return err
+}
+
+// flushElement is called before writing each element of an array flushed every n elements.
+func (enc *Encoder) flushElement() {
+	if enc.flush.count > 0 && enc.flush.count%enc.flush.every == 0 && enc.flush.err == nil {
+		enc.flush.err = enc.Flush()
+	}
+	enc.flush.count++
+}
+
+// AddArrayKeyBudgeted adds an array to be encoded, must be used inside an object as it will encode a key
+// produce is called repeatedly to add the elements of the array, each call must add one element and return true,
+// or return false without adding anything once there are no more elements.
+//
+// budget is the maximum number of bytes of the array, from its opening to its closing bracket.
+// Once an element would make the array exceed budget, it is removed and the following ones are produced
+// only to be counted and discarded. The array then ends with a string marker "...truncated N more",
+// N being the number of elements removed. The marker itself is not counted in budget.
+func (enc *Encoder) AddArrayKeyBudgeted(key string, budget int, produce func(*Encoder) bool) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddArrayKeyBudgeted(key, budget, produce) })
+	}
+	statsStart := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	start := len(enc.buf)
+	enc.writeOpen('[')
+	truncated := 0
+	// elements are truncated from the buffer, it must not be flushed meanwhile
+	enc.pinned++
+	for {
+		mark, hasValue := len(enc.buf), enc.hasValue
+		if !produce(enc) {
+			break
+		}
+		// keep room for the closing bracket
+		if truncated > 0 || len(enc.buf)-start+1 > budget {
+			enc.buf = enc.buf[:mark]
+			enc.hasValue = hasValue
+			truncated++
+		}
+	}
+	enc.pinned--
+	if truncated > 0 {
+		enc.AddString("...truncated " + strconv.Itoa(truncated) + " more")
+	}
+	enc.writeClose(']')
+	enc.record(KindArray, statsStart)
 	return nil
 }
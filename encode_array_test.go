@@ -58,9 +58,9 @@ func TestEncoderArrayObjects(t *testing.T) {
 		`[{"test":"hello world","test2":"漢字","testInt":1,"testBool":true,`+
 			`"testArr":[],"testF64":0,"testF32":0,"testInterface":1,"sub":{"test1":10,"test2":"hello world",`+
 			`"test3":1.23543,"testBool":true,"sub":{"test1":10,"test2":"hello world",`+
-			`"test3":0,"testBool":false}}},{"test":"hello world","test2":"漢字","testInt":1,`+
+			`"test3":0,"testBool":false,"sub":null}}},{"test":"hello world","test2":"漢字","testInt":1,`+
 			`"testBool":true,"testArr":[],"testF64":0,"testF32":0,"sub":{"test1":10,"test2":"hello world","test3":1.23543,`+
-			`"testBool":true,"sub":{"test1":10,"test2":"hello world","test3":0,"testBool":false}}}]`,
+			`"testBool":true,"sub":{"test1":10,"test2":"hello world","test3":0,"testBool":false,"sub":null}}}]`,
 		string(r),
 		"Result of marshalling is different as the one expected")
 }
@@ -100,7 +100,121 @@ func TestEncoderArrayInterfaces(t *testing.T) {
 	assert.Nil(t, err, "Error should be nil")
 	assert.Equal(
 		t,
-		`[1,1,1,1,1,1,1,1,1.31,[],true,"test",{"test":"hello world","test2":"foobar","testInt":1,"testBool":true,"testArr":[],"testF64":0,"testF32":0}]`,
+		`[1,1,1,1,1,1,1,1,1,1.31,[],true,"test",{"test":"hello world","test2":"foobar","testInt":1,"testBool":true,"testArr":[],"testF64":0,"testF32":0,"sub":null}]`,
 		string(r),
 		"Result of marshalling is different as the one expected")
 }
+
+type testFlushingInts []int
+
+func (t testFlushingInts) MarshalArray(enc *Encoder) {
+	for _, i := range t {
+		enc.AddInt(i)
+	}
+}
+
+func TestEncoderArrayKeyFlushing(t *testing.T) {
+	w := &testFlushWriter{}
+	enc := NewEncoderWriter(w)
+	defer enc.addToPool()
+	ints := make(testFlushingInts, 10)
+	for i := range ints {
+		ints[i] = i
+	}
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddArrayKeyFlushing("ints", ints, 3)
+		enc.AddArrayKey("other", ints[:4])
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	// flushed before elements 3, 6 and 9
+	assert.Equal(t, 3, w.writes, "array should have been flushed every 3 elements")
+	assert.Equal(t, `{"ints":[0,1,2,3,4,5,6,7,8`, w.String(), "flushed bytes should be the expected ones")
+	err = enc.Flush()
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"ints":[0,1,2,3,4,5,6,7,8,9],"other":[0,1,2,3]}`, w.String(), "Result of marshalling is different as the one expected")
+}
+
+func TestEncoderArrayFlushingObjects(t *testing.T) {
+	w := &testFlushWriter{}
+	enc := NewEncoderWriter(w)
+	defer enc.addToPool()
+	err := enc.AddArrayFlushing(EncodeArrayFunc(func(enc *Encoder) {
+		for i := 0; i < 5; i++ {
+			enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+				enc.AddIntKey("a", 1)
+				enc.AddIntKey("b", 2)
+			}))
+		}
+	}), 2)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, 2, w.writes, "array should have been flushed every 2 elements")
+	enc.Flush()
+	assert.Equal(t, `[{"a":1,"b":2},{"a":1,"b":2},{"a":1,"b":2},{"a":1,"b":2},{"a":1,"b":2}]`, w.String(), "Result of marshalling is different as the one expected")
+}
+
+func TestEncoderArrayFlushingNoWriter(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	err := enc.AddArrayFlushing(testFlushingInts{1}, 2)
+	assert.IsType(t, NoWriterError(""), err, "err should be of type NoWriterError")
+}
+
+func TestEncoderArrayKeyBudgeted(t *testing.T) {
+	testCases := []struct {
+		name     string
+		budget   int
+		n        int
+		expected string
+	}{
+		{
+			name:     "within-budget",
+			budget:   100,
+			n:        3,
+			expected: `{"arr":["elem","elem","elem"],"after":true}`,
+		},
+		{
+			name:     "truncated",
+			budget:   22,
+			n:        10,
+			expected: `{"arr":["elem","elem","elem","...truncated 7 more"],"after":true}`,
+		},
+		{
+			name:     "exact-budget",
+			budget:   22,
+			n:        3,
+			expected: `{"arr":["elem","elem","elem"],"after":true}`,
+		},
+		{
+			name:     "first-element-over-budget",
+			budget:   5,
+			n:        2,
+			expected: `{"arr":["...truncated 2 more"],"after":true}`,
+		},
+		{
+			name:     "empty",
+			budget:   5,
+			expected: `{"arr":[],"after":true}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enc := NewEncoder()
+			defer enc.addToPool()
+			produced := 0
+			err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+				enc.AddArrayKeyBudgeted("arr", testCase.budget, func(enc *Encoder) bool {
+					if produced == testCase.n {
+						return false
+					}
+					produced++
+					enc.AddString("elem")
+					return true
+				})
+				enc.AddBoolKey("after", true)
+			}))
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+			assert.Equal(t, testCase.n, produced, "every element should have been produced")
+		})
+	}
+}
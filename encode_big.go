@@ -0,0 +1,87 @@
+package gojay
+
+import "math/big"
+
+// AddBigInt adds a *big.Int to be encoded, must be used inside a slice or array encoding (does not encode a key)
+// Its digits are written directly to the buffer, whatever its size. If v is nil, null is written.
+func (enc *Encoder) AddBigInt(v *big.Int) error {
+	if v == nil {
+		return enc.AddNull()
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.buf = v.Append(enc.buf, 10)
+	enc.record(KindNumber, start)
+	return nil
+}
+
+// AddBigIntKey adds a *big.Int to be encoded, must be used inside an object as it will encode a key
+// Its digits are written directly to the buffer, whatever its size. If v is nil, null is written.
+func (enc *Encoder) AddBigIntKey(key string, v *big.Int) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddBigIntKey(key, v) })
+	}
+	if v == nil {
+		return enc.AddNullKey(key)
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	enc.buf = v.Append(enc.buf, 10)
+	enc.record(KindNumber, start)
+	return nil
+}
+
+// AddBigFloat adds a *big.Float to be encoded, must be used inside a slice or array encoding (does not encode a key)
+// It is written directly to the buffer with the smallest number of digits representing it exactly at its precision,
+// without exponent. If v is nil, null is written. If v is infinite, nothing is written and an InvalidTypeError is returned,
+// it is also set with SetError.
+func (enc *Encoder) AddBigFloat(v *big.Float) error {
+	if v == nil {
+		return enc.AddNull()
+	}
+	if v.IsInf() {
+		err := InvalidTypeError("Cannot marshal an infinite big.Float")
+		enc.SetError(err)
+		return err
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.buf = v.Append(enc.buf, 'f', -1)
+	enc.record(KindNumber, start)
+	return nil
+}
+
+// AddBigFloatKey adds a *big.Float to be encoded, must be used inside an object as it will encode a key
+// It is written directly to the buffer with the smallest number of digits representing it exactly at its precision,
+// without exponent. If v is nil, null is written. If v is infinite, nothing is written and an InvalidTypeError is returned,
+// it is also set with SetError.
+func (enc *Encoder) AddBigFloatKey(key string, v *big.Float) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddBigFloatKey(key, v) })
+	}
+	if v == nil {
+		return enc.AddNullKey(key)
+	}
+	if v.IsInf() {
+		err := InvalidTypeError("Cannot marshal an infinite big.Float")
+		enc.SetError(err)
+		return err
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	enc.buf = v.Append(enc.buf, 'f', -1)
+	enc.record(KindNumber, start)
+	return nil
+}
@@ -0,0 +1,86 @@
+package gojay
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderBigInt(t *testing.T) {
+	huge := new(big.Int).Lsh(big.NewInt(1), 100)
+	r, err := Marshal(huge)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `1267650600228229401496703205376`, string(r), "Result of marshalling is different as the one expected")
+
+	var nilInt *big.Int
+	r, err = Marshal(nilInt)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `null`, string(r), "Result of marshalling is different as the one expected")
+
+	r, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddBigIntKey("huge", huge)
+		enc.AddBigIntKey("neg", big.NewInt(-42))
+		enc.AddBigIntKey("nil", nil)
+		enc.AddInterfaceKey("iface", big.NewInt(7))
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddBigInt(big.NewInt(0))
+			enc.AddBigInt(nil)
+			enc.AddInterface(big.NewInt(1))
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"huge":1267650600228229401496703205376,"neg":-42,"nil":null,"iface":7,"arr":[0,null,1]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderBigFloat(t *testing.T) {
+	r, err := Marshal(big.NewFloat(0.1))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `0.1`, string(r), "Result of marshalling is different as the one expected")
+
+	huge, _ := new(big.Float).SetPrec(200).SetString("123456789012345678901234567890.125")
+	r, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddBigFloatKey("huge", huge)
+		enc.AddBigFloatKey("neg", big.NewFloat(-1.5))
+		enc.AddBigFloatKey("nil", nil)
+		enc.AddInterfaceKey("iface", big.NewFloat(1e21))
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddBigFloat(big.NewFloat(0))
+			enc.AddBigFloat(nil)
+			enc.AddInterface(big.NewFloat(2.5))
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"huge":123456789012345678901234567890.125,"neg":-1.5,"nil":null,"iface":1000000000000000000000,"arr":[0,null,2.5]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderBigFloatInf(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.writeByte('[')
+	enc.AddBigFloat(big.NewFloat(1))
+	err := enc.AddBigFloat(big.NewFloat(math.Inf(1)))
+	assert.NotNil(t, err, "Error should not be nil")
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	err = enc.AddBigFloatKey("inf", big.NewFloat(math.Inf(-1)))
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	assert.Equal(t, `[1`, string(enc.Bytes()), "nothing should be written")
+	assert.IsType(t, InvalidTypeError(""), enc.Err(), "error should be set on the encoder")
+
+	_, err = Marshal(big.NewFloat(math.Inf(1)))
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	_, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddBigFloatKey("inf", big.NewFloat(math.Inf(1)))
+		enc.AddIntKey("ok", 1)
+	}))
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+}
@@ -4,27 +4,40 @@ import "strconv"
 
 // AddBool adds a bool to be encoded, must be used inside a slice or array encoding (does not encode a key)
 func (enc *Encoder) AddBool(value bool) error {
-	r, ok := enc.getPreviousRune()
-	if ok && r != '[' {
-		enc.writeByte(',')
-	}
+	start := enc.offset()
+	enc.writeSep()
 	if value {
 		enc.writeString("true")
 	} else {
 		enc.writeString("false")
 	}
+	enc.record(KindBool, start)
 	return nil
 }
 
 // AddBoolKey adds a bool to be encoded, must be used inside an object as it will encode a key
 func (enc *Encoder) AddBoolKey(key string, value bool) error {
-	r, ok := enc.getPreviousRune()
-	if ok && r != '{' && r != '[' {
-		enc.writeByte(',')
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddBoolKey(key, value) })
 	}
+	start := enc.offset()
+	enc.writeSep()
 	enc.writeByte('"')
-	enc.writeString(key)
-	enc.write(objKey)
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
 	enc.buf = strconv.AppendBool(enc.buf, value)
+	enc.record(KindBool, start)
 	return nil
 }
+
+// AddBoolKeyOmitEmpty adds a bool to be encoded, must be used inside an object as it will encode a key
+// If value is false, nothing is written, not even the key.
+func (enc *Encoder) AddBoolKeyOmitEmpty(key string, value bool) error {
+	if !value {
+		return nil
+	}
+	return enc.AddBoolKey(key, value)
+}
@@ -4,6 +4,10 @@
 
 package gojay
 
+// growLargeBuffer is the capacity from which grow stops doubling the buffer and grows it by a quarter,
+// so that large documents are not held in buffers up to twice their size.
+const growLargeBuffer = 1 << 20
+
 // grow grows b's capacity, if necessary, to guarantee space for
 // another n bytes. After grow(n), at least n bytes can be written to b
 // without another allocation. If n is negative, grow panics.
@@ -12,7 +16,16 @@ func (enc *Encoder) grow(n int) {
 		panic("Builder.grow: negative count")
 	}
 	if cap(enc.buf)-len(enc.buf) < n {
-		Buf := make([]byte, len(enc.buf), 2*cap(enc.buf)+n)
+		c := cap(enc.buf)
+		if c < growLargeBuffer {
+			c = 2 * c
+		} else {
+			c += c / 4
+		}
+		if c < len(enc.buf)+n {
+			c = len(enc.buf) + n
+		}
+		Buf := make([]byte, len(enc.buf), c)
 		copy(Buf, enc.buf)
 		enc.buf = Buf
 	}
@@ -0,0 +1,49 @@
+package gojay
+
+import "encoding/base64"
+
+// AddBytes adds a []byte to be encoded as a base64 string, must be used inside a slice or array encoding (does not encode a key)
+// It is encoded straight into the buffer with the standard base64 encoding, as encoding/json does, a nil slice is encoded as null.
+func (enc *Encoder) AddBytes(b []byte) error {
+	if b == nil {
+		return enc.AddNull()
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeBase64(b)
+	enc.record(KindString, start)
+	return nil
+}
+
+// AddBytesKey adds a []byte to be encoded as a base64 string, must be used inside an object as it will encode a key
+// It is encoded straight into the buffer with the standard base64 encoding, as encoding/json does, a nil slice is encoded as null.
+func (enc *Encoder) AddBytesKey(key string, b []byte) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddBytesKey(key, b) })
+	}
+	if b == nil {
+		return enc.AddNullKey(key)
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	enc.writeBase64(b)
+	enc.record(KindString, start)
+	return nil
+}
+
+// writeBase64 writes b as a base64 encoded string, as encoding/json does for []byte.
+func (enc *Encoder) writeBase64(b []byte) {
+	enc.writeByte('"')
+	l := len(enc.buf)
+	n := base64.StdEncoding.EncodedLen(len(b))
+	enc.grow(n)
+	enc.buf = enc.buf[:l+n]
+	base64.StdEncoding.Encode(enc.buf[l:], b)
+	enc.writeByte('"')
+}
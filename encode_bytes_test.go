@@ -0,0 +1,27 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderBytes(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddBytesKey("data", []byte("hello world"))
+		enc.AddBytesKey("empty", []byte{})
+		enc.AddBytesKey("nil", nil)
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddBytes([]byte{0xff, 0xff})
+			enc.AddBytes(nil)
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"data":"aGVsbG8gd29ybGQ=","empty":"","nil":null,"arr":["//8=",null]}`,
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+}
@@ -0,0 +1,128 @@
+package gojay
+
+import (
+	"math"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// MarshalCanonical returns the canonical JSON encoding of v, as defined by RFC 8785 (JSON Canonicalization Scheme),
+// so that the output can be hashed or signed. v must implement MarshalerObject or MarshalerArray.
+//
+// Keys of every object are sorted by their UTF-16 code units, strings and keys are escaped minimally,
+// floats are written in their shortest form, formatted as ECMAScript does, and no whitespace is written.
+// Integers are written as is, they should not exceed 2^53 in magnitude to be canonical.
+// EmbeddedJSON is written as is, it must already be canonical. NaN and infinite floats are not supported.
+func MarshalCanonical(v interface{}) ([]byte, error) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.canonical = true
+	enc.SetSortKeys(true)
+	switch vt := v.(type) {
+	case MarshalerObject:
+		enc.writeObject(vt)
+	case MarshalerArray:
+		enc.writeArray(vt)
+	default:
+		return nil, InvalidTypeError("Unknown type to Marshal")
+	}
+	return enc.encoded()
+}
+
+// appendCanonicalFloat appends f to b formatted as ECMAScript's Number.prototype.toString does.
+func appendCanonicalFloat(b []byte, f float64) []byte {
+	if f == 0 {
+		// negative zero is written as 0
+		return append(b, '0')
+	}
+	abs := math.Abs(f)
+	if abs >= 1e-6 && abs < 1e21 {
+		return strconv.AppendFloat(b, f, 'f', -1, 64)
+	}
+	b = strconv.AppendFloat(b, f, 'e', -1, 64)
+	// strconv writes at least two exponent digits, for example 1e-07 instead of 1e-7
+	n := len(b)
+	if n >= 4 && b[n-4] == 'e' && b[n-2] == '0' {
+		b[n-2] = b[n-1]
+		b = b[:n-1]
+	}
+	return b
+}
+
+// utf16Less reports whether a sorts before b when comparing their UTF-16 code units.
+func utf16Less(a, b string) bool {
+	ua := utf16.Encode([]rune(a))
+	ub := utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
+
+// unescapeKey returns the key encoded in b without its escape sequences.
+func unescapeKey(b []byte) string {
+	i := 0
+	for i < len(b) && b[i] != '\\' {
+		i++
+	}
+	if i == len(b) {
+		return string(b)
+	}
+	s := make([]byte, 0, len(b))
+	s = append(s, b[:i]...)
+	for ; i < len(b); i++ {
+		if b[i] != '\\' || i+1 == len(b) {
+			s = append(s, b[i])
+			continue
+		}
+		i++
+		switch b[i] {
+		case 'b':
+			s = append(s, '\b')
+		case 'f':
+			s = append(s, '\f')
+		case 'n':
+			s = append(s, '\n')
+		case 'r':
+			s = append(s, '\r')
+		case 't':
+			s = append(s, '\t')
+		case 'u':
+			r, n := unescapeUnicode(b[i+1:])
+			s = appendRune(s, r)
+			i += n
+		default:
+			s = append(s, b[i])
+		}
+	}
+	return string(s)
+}
+
+// unescapeUnicode decodes the hex digits following a \u escape in b, and the low surrogate escape following them if any.
+// It returns the rune and the number of bytes read.
+func unescapeUnicode(b []byte) (rune, int) {
+	if len(b) < 4 {
+		return utf8.RuneError, len(b)
+	}
+	r1, err := strconv.ParseUint(string(b[:4]), 16, 16)
+	if err != nil {
+		return utf8.RuneError, 4
+	}
+	if utf16.IsSurrogate(rune(r1)) && len(b) >= 10 && b[4] == '\\' && b[5] == 'u' {
+		if r2, err := strconv.ParseUint(string(b[6:10]), 16, 16); err == nil {
+			if r := utf16.DecodeRune(rune(r1), rune(r2)); r != utf8.RuneError {
+				return r, 10
+			}
+		}
+	}
+	return rune(r1), 4
+}
+
+func appendRune(b []byte, r rune) []byte {
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	return append(b, buf[:n]...)
+}
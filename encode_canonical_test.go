@@ -0,0 +1,59 @@
+package gojay
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalCanonical(t *testing.T) {
+	v := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("\u20ac", "Euro Sign")
+		enc.AddStringKey("\r", "Carriage Return")
+		enc.AddStringKey("\ufb33", "Hebrew Letter Dalet With Dagesh")
+		enc.AddStringKey("1", "One")
+		enc.AddStringKey("\U0001f600", "Emoji: Grinning Face")
+		enc.AddStringKey("\u0080", "Control")
+		enc.AddStringKey("\u00f6", "Latin Small Letter O With Diaeresis")
+	})
+	r, err := MarshalCanonical(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		"{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\","+
+			"\"\u20ac\":\"Euro Sign\",\"\U0001f600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		string(r),
+		"keys should be sorted by their UTF-16 code units")
+
+	r, err = MarshalCanonical(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddArrayKey("numbers", EncodeArrayFunc(func(enc *Encoder) {
+			for _, f := range []float64{333333333.33333329, 1e30, 4.50, 2e-3, 0.000000000000000000000000001, math.Copysign(0, -1), 1e-7, 1e21, 1e20} {
+				enc.AddFloat(f)
+			}
+		}))
+		enc.AddFloat32Key("float32", 0.5)
+		enc.AddStringKey("string", "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"/")
+		enc.AddObjectKey("literals", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddNullKey("null")
+			enc.AddBoolKey("false", false)
+			enc.AddBoolKey("true", true)
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"float32":0.5,"literals":{"false":false,"null":null,"true":true},`+
+			`"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27,0,1e-7,1e+21,100000000000000000000],`+
+			`"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+
+	_, err = MarshalCanonical("string")
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+}
+
+func TestUnescapeKey(t *testing.T) {
+	assert.Equal(t, "plain", unescapeKey([]byte(`plain`)))
+	assert.Equal(t, "a\"b\\c\n\t\u001f\U0001f600", unescapeKey([]byte(`a\"b\\c\n\t\u001f\ud83d\ude00`)))
+}
@@ -0,0 +1,35 @@
+package gojay
+
+// Checkpoint is a position in the output of an Encoder, returned by Encoder.Checkpoint.
+type Checkpoint struct {
+	offset   int
+	hasValue bool
+	depth    int
+}
+
+// Checkpoint returns the current position in the object or array being encoded,
+// so that the values added afterwards can be removed with Rollback.
+func (enc *Encoder) Checkpoint() Checkpoint {
+	return Checkpoint{
+		offset:   enc.offset(),
+		hasValue: enc.hasValue,
+		depth:    enc.depth,
+	}
+}
+
+// Rollback removes everything written since c was returned by Checkpoint, keys and separators included,
+// so that the object or array being encoded is left as if the values had never been added.
+// The values removed are still counted in the Stats.
+//
+// c must have been taken in the object or array being encoded, and the bytes written since
+// must not have been flushed, otherwise a RollbackError is returned and nothing is removed.
+func (enc *Encoder) Rollback(c Checkpoint) error {
+	if c.depth != enc.depth {
+		return RollbackError("Cannot rollback, the checkpoint was taken in another object or array")
+	}
+	if c.offset < enc.written || c.offset > enc.offset() {
+		return RollbackError("Cannot rollback, the bytes written since the checkpoint were flushed")
+	}
+	enc.truncate(c.offset-enc.written, c.hasValue)
+	return nil
+}
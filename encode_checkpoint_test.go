@@ -0,0 +1,67 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderCheckpoint(t *testing.T) {
+	v := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("id", "a")
+		c := enc.Checkpoint()
+		enc.AddStringKey("owner", "john")
+		enc.AddObjectKey("profile", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntKey("age", 30)
+		}))
+		err := enc.Rollback(c)
+		assert.Nil(t, err, "Error should be nil")
+		enc.AddIntKey("version", 2)
+	})
+	r, err := MarshalObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"id":"a","version":2}`, string(r), "Result of marshalling is different as the one expected")
+
+	r, err = MarshalArray(EncodeArrayFunc(func(enc *Encoder) {
+		c := enc.Checkpoint()
+		enc.AddInt(1)
+		enc.Rollback(c)
+		enc.AddInt(2)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `[2]`, string(r), "first element should be rolled back with its separator")
+
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetSortKeys(true)
+	err = enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("b", "b")
+		c := enc.Checkpoint()
+		enc.AddStringKey("a", "a")
+		enc.Rollback(c)
+		enc.AddStringKey("c", "c")
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"b":"b","c":"c"}`, string(enc.Bytes()), "rolled back keys should not be sorted")
+}
+
+func TestEncoderRollbackErrors(t *testing.T) {
+	var depthErr, flushErr error
+	builder := &strings.Builder{}
+	enc := NewEncoderWriter(builder)
+	defer enc.addToPool()
+	enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		c := enc.Checkpoint()
+		enc.AddObjectKey("sub", EncodeObjectFunc(func(enc *Encoder) {
+			depthErr = enc.Rollback(c)
+		}))
+		enc.AddStringKey("a", "a")
+		enc.Flush()
+		flushErr = enc.Rollback(c)
+	}))
+	assert.IsType(t, RollbackError(""), depthErr, "err should be of type RollbackError")
+	assert.IsType(t, RollbackError(""), flushErr, "err should be of type RollbackError")
+	enc.Flush()
+	assert.Equal(t, `{"sub":{},"a":"a"}`, builder.String(), "nothing should be removed")
+}
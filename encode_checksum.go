@@ -0,0 +1,71 @@
+package gojay
+
+import (
+	"encoding/hex"
+	"hash"
+)
+
+// WithChecksum adds a key whose value is the hex encoded checksum computed by h over the bytes
+// written so far, must be used inside an object as it will encode a key.
+// The key is written when the object is closed, as its last field, whatever the fields added after the call.
+//
+// The checksum is computed when the object is closed, once its keys are sorted if SetSortKeys is set,
+// so the exact range hashed is every byte written by the Encoder before the closing brace:
+// from the first byte of the output up to the last byte of the last field of the object.
+// The comma separating the checksum field, the checksum field itself and the closing braces are not hashed.
+// For example, for {"id":1,"sum":"..."} the hashed bytes are {"id":1
+// Objects enclosing the object are sorted after it is closed, WithChecksum should be used in the top level object
+// for the hashed bytes to be a prefix of the output when SetSortKeys is set.
+//
+// Bytes are written to h as is, h should be new or reset.
+// Bytes already flushed to the Encoder's io.Writer are not hashed, so Flush must not be called before the object is closed,
+// nor can it be used in a value written by EncodeObject, EncodeArray or Encode.
+func (enc *Encoder) WithChecksum(key string, h hash.Hash) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.WithChecksum(key, h) })
+	}
+	enc.checksums = append(enc.checksums, pendingChecksum{enc.depth, key, h})
+	return nil
+}
+
+// pendingChecksum is a checksum field added by WithChecksum to the object opened at depth.
+type pendingChecksum struct {
+	depth int
+	key   string
+	h     hash.Hash
+}
+
+// writeChecksum writes the checksum field of the object being closed by c, it is called once the object is sorted.
+// The field is not a field of the sort frames, so that it stays last.
+func (enc *Encoder) writeChecksum(c byte) {
+	n := len(enc.checksums)
+	p := enc.checksums[n-1]
+	enc.checksums = enc.checksums[:n-1]
+	if c != '}' {
+		return
+	}
+	if _, err := p.h.Write(enc.buf); err != nil {
+		enc.SetError(err)
+		return
+	}
+	sum := p.h.Sum(nil)
+	if enc.hasValue {
+		enc.writeByte(',')
+	}
+	if enc.indented && enc.compact == 0 {
+		enc.writeIndent(p.depth)
+	}
+	enc.writeByte('"')
+	enc.writeKey(p.key)
+	enc.writeObjKey(objKeyStr)
+	l := len(enc.buf)
+	n = hex.EncodedLen(len(sum))
+	enc.grow(n)
+	enc.buf = enc.buf[:l+n]
+	hex.Encode(enc.buf[l:], sum)
+	enc.writeByte('"')
+	enc.hasValue = true
+}
@@ -0,0 +1,78 @@
+package gojay
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testChecksumObject struct {
+	id   int
+	name string
+}
+
+func (t *testChecksumObject) IsNil() bool {
+	return t == nil
+}
+
+func (t *testChecksumObject) MarshalObject(enc *Encoder) {
+	enc.AddIntKey("id", t.id)
+	enc.AddStringKey("name", t.name)
+	enc.WithChecksum("checksum", sha256.New())
+}
+
+func TestEncoderWithChecksum(t *testing.T) {
+	r, err := MarshalObject(&testChecksumObject{1, "gojay"})
+	assert.Nil(t, err, "Error should be nil")
+	sum := sha256.Sum256([]byte(`{"id":1,"name":"gojay"`))
+	assert.Equal(
+		t,
+		`{"id":1,"name":"gojay","checksum":"`+hex.EncodeToString(sum[:])+`"}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+type testChecksumEmptyObject struct{}
+
+func (t *testChecksumEmptyObject) IsNil() bool {
+	return t == nil
+}
+
+func (t *testChecksumEmptyObject) MarshalObject(enc *Encoder) {
+	enc.WithChecksum("checksum", sha256.New())
+}
+
+func TestEncoderWithChecksumEmptyObject(t *testing.T) {
+	r, err := MarshalObject(&testChecksumEmptyObject{})
+	assert.Nil(t, err, "Error should be nil")
+	sum := sha256.Sum256([]byte(`{`))
+	assert.Equal(
+		t,
+		`{"checksum":"`+hex.EncodeToString(sum[:])+`"}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderWithChecksumSortKeys(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetSortKeys(true)
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("name", "gojay")
+		enc.WithChecksum("checksum", sha256.New())
+		enc.AddObjectKey("sub", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntKey("b", 2)
+			enc.AddIntKey("a", 1)
+		}))
+		enc.AddIntKey("id", 1)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	sum := sha256.Sum256([]byte(`{"id":1,"name":"gojay","sub":{"a":1,"b":2}`))
+	assert.Equal(
+		t,
+		`{"id":1,"name":"gojay","sub":{"a":1,"b":2},"checksum":"`+hex.EncodeToString(sum[:])+`"}`,
+		string(enc.Bytes()),
+		"checksum should be the last field, computed over the sorted object")
+}
@@ -0,0 +1,164 @@
+package gojay
+
+import (
+	"strconv"
+	"time"
+)
+
+// DurationFormat is the format time.Durations are encoded to by AddDuration and AddDurationKey.
+type DurationFormat int
+
+// Formats of time.Durations.
+const (
+	// DurationNanoseconds encodes durations as an integer number of nanoseconds, such as 5400000000000
+	DurationNanoseconds DurationFormat = iota
+	// DurationString encodes durations as a string formatted as time.Duration.String does, such as "1h30m0s"
+	DurationString
+	// DurationSeconds encodes durations as a float number of seconds, such as 5400
+	DurationSeconds
+)
+
+// SetDurationFormat sets the format of the durations added with AddDuration and AddDurationKey,
+// DurationNanoseconds if not set.
+func (enc *Encoder) SetDurationFormat(format DurationFormat) {
+	enc.durationFormat = format
+}
+
+// AddDuration adds a time.Duration to be encoded, must be used inside a slice or array encoding (does not encode a key)
+// It is formatted as set by SetDurationFormat, without allocating.
+func (enc *Encoder) AddDuration(d time.Duration) error {
+	start := enc.offset()
+	enc.writeSep()
+	enc.record(enc.writeDuration(d), start)
+	return nil
+}
+
+// AddDurationKey adds a time.Duration to be encoded, must be used inside an object as it will encode a key
+// It is formatted as set by SetDurationFormat, without allocating.
+func (enc *Encoder) AddDurationKey(key string, d time.Duration) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddDurationKey(key, d) })
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	enc.record(enc.writeDuration(d), start)
+	return nil
+}
+
+// writeDuration writes d in the format of the Encoder and returns the Kind of the value written.
+func (enc *Encoder) writeDuration(d time.Duration) Kind {
+	switch enc.durationFormat {
+	case DurationString:
+		var b [32]byte
+		w := formatDuration(&b, d)
+		enc.writeByte('"')
+		enc.write(b[w:])
+		enc.writeByte('"')
+		return KindString
+	case DurationSeconds:
+		enc.writeFloat(d.Seconds(), 64)
+	default:
+		enc.buf = strconv.AppendInt(enc.buf, int64(d), 10)
+	}
+	return KindNumber
+}
+
+// formatDuration formats d at the end of buf as time.Duration.String does and returns the index of its first byte.
+func formatDuration(buf *[32]byte, d time.Duration) int {
+	w := len(buf)
+	u := uint64(d)
+	neg := d < 0
+	if neg {
+		u = -u
+	}
+	if u < uint64(time.Second) {
+		// less than a second, use a smaller unit, such as 1.2ms
+		var prec int
+		w--
+		buf[w] = 's'
+		w--
+		switch {
+		case u == 0:
+			buf[w] = '0'
+			return w
+		case u < uint64(time.Microsecond):
+			prec = 0
+			buf[w] = 'n'
+		case u < uint64(time.Millisecond):
+			prec = 3
+			// 'µ' is 2 bytes long
+			w--
+			copy(buf[w:], "µ")
+		default:
+			prec = 6
+			buf[w] = 'm'
+		}
+		w, u = formatFrac(buf[:w], u, prec)
+		w = formatUint(buf[:w], u)
+	} else {
+		w--
+		buf[w] = 's'
+		w, u = formatFrac(buf[:w], u, 9)
+		w = formatUint(buf[:w], u%60)
+		u /= 60
+		if u > 0 {
+			w--
+			buf[w] = 'm'
+			w = formatUint(buf[:w], u%60)
+			u /= 60
+			if u > 0 {
+				w--
+				buf[w] = 'h'
+				w = formatUint(buf[:w], u)
+			}
+		}
+	}
+	if neg {
+		w--
+		buf[w] = '-'
+	}
+	return w
+}
+
+// formatFrac formats the fraction of v/10^prec at the end of buf, omitting trailing zeros and the dot if the fraction is 0.
+// It returns the index of its first byte and v/10^prec.
+func formatFrac(buf []byte, v uint64, prec int) (int, uint64) {
+	w := len(buf)
+	printed := false
+	for i := 0; i < prec; i++ {
+		digit := v % 10
+		printed = printed || digit != 0
+		if printed {
+			w--
+			buf[w] = byte(digit) + '0'
+		}
+		v /= 10
+	}
+	if printed {
+		w--
+		buf[w] = '.'
+	}
+	return w, v
+}
+
+// formatUint formats v at the end of buf and returns the index of its first byte.
+func formatUint(buf []byte, v uint64) int {
+	w := len(buf)
+	if v == 0 {
+		w--
+		buf[w] = '0'
+		return w
+	}
+	for v > 0 {
+		w--
+		buf[w] = byte(v%10) + '0'
+		v /= 10
+	}
+	return w
+}
@@ -0,0 +1,63 @@
+package gojay
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderDuration(t *testing.T) {
+	v := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddDurationKey("d", 90*time.Minute)
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddDuration(1500 * time.Millisecond)
+			enc.AddDuration(-2 * time.Microsecond)
+		}))
+	})
+	testCases := []struct {
+		name     string
+		format   DurationFormat
+		expected string
+	}{
+		{
+			name:     "nanoseconds",
+			format:   DurationNanoseconds,
+			expected: `{"d":5400000000000,"arr":[1500000000,-2000]}`,
+		},
+		{
+			name:     "string",
+			format:   DurationString,
+			expected: `{"d":"1h30m0s","arr":["1.5s","-2µs"]}`,
+		},
+		{
+			name:     "seconds",
+			format:   DurationSeconds,
+			expected: `{"d":5400,"arr":[1.5,-0.000002]}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enc := NewEncoder()
+			defer enc.addToPool()
+			enc.SetDurationFormat(testCase.format)
+			err := enc.AddObject(v)
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	durations := []time.Duration{
+		0, 1, 999, time.Microsecond, 1100 * time.Nanosecond, time.Millisecond + 1,
+		time.Second, 61 * time.Second, 3*time.Hour + 2*time.Millisecond, 100000 * time.Hour,
+		-time.Nanosecond, -time.Hour, math.MaxInt64, math.MinInt64,
+	}
+	for _, d := range durations {
+		var b [32]byte
+		w := formatDuration(&b, d)
+		assert.Equal(t, d.String(), string(b[w:]), "duration should be formatted as time.Duration.String does")
+	}
+}
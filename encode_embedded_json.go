@@ -0,0 +1,102 @@
+package gojay
+
+// EmbeddedJSON is a raw encoded JSON value.
+// It can be used to splice pre-encoded JSON into the output of an Encoder.
+// A []byte can be passed directly where an EmbeddedJSON is expected, it is written without being re-encoded nor escaped.
+type EmbeddedJSON []byte
+
+// SetMinifyEmbedded sets whether embedded JSON added through AddEmbeddedJSON and AddEmbeddedJSONKey
+// should be stripped of its insignificant whitespace before being written.
+func (enc *Encoder) SetMinifyEmbedded(minify bool) {
+	enc.minifyEmbedded = minify
+}
+
+// AddEmbeddedJSON adds an EmbeddedJSON to be encoded, must be used inside a slice or array encoding (does not encode a key)
+// value is written as is, unless SetMinifyEmbedded(true) was called on the Encoder.
+func (enc *Encoder) AddEmbeddedJSON(value EmbeddedJSON) error {
+	start, hasValue := len(enc.buf), enc.hasValue
+	statsStart := enc.offset()
+	enc.writeSep()
+	if err := enc.writeEmbeddedJSON(start, hasValue, value); err != nil {
+		return err
+	}
+	enc.record(embeddedKind(value), statsStart)
+	return nil
+}
+
+// AddEmbeddedJSONKey adds an EmbeddedJSON to be encoded, must be used inside an object as it will encode a key
+// value is written as is, unless SetMinifyEmbedded(true) was called on the Encoder.
+func (enc *Encoder) AddEmbeddedJSONKey(key string, value EmbeddedJSON) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddEmbeddedJSONKey(key, value) })
+	}
+	start, hasValue := len(enc.buf), enc.hasValue
+	statsStart := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	if err := enc.writeEmbeddedJSON(start, hasValue, value); err != nil {
+		return err
+	}
+	enc.record(embeddedKind(value), statsStart)
+	return nil
+}
+
+// writeEmbeddedJSON writes value to the buffer, minifying it if required.
+// If value cannot be minified, the buffer is truncated back to start and the separator state restored to hasValue.
+func (enc *Encoder) writeEmbeddedJSON(start int, hasValue bool, value EmbeddedJSON) error {
+	if !enc.minifyEmbedded {
+		enc.write(value)
+		return nil
+	}
+	b, err := appendMinified(enc.buf, value)
+	if err != nil {
+		enc.truncate(start, hasValue)
+		return err
+	}
+	enc.buf = b
+	return nil
+}
+
+// embeddedKind returns the Kind of the JSON value in value, 0 if it does not start with a JSON value.
+func embeddedKind(value EmbeddedJSON) Kind {
+	for _, c := range value {
+		switch c {
+		case ' ', '\n', '\t', '\r':
+			continue
+		}
+		return kindOf(c)
+	}
+	return 0
+}
+
+// Minify returns a copy of data stripped of all whitespace found outside of strings.
+//
+// String contents are kept exactly as they are, escape sequences included.
+// data must hold a single JSON value, it is read by the Decoder's tokenizer as strictly as Walk reads its input:
+// if it is malformed or followed by anything but whitespace, an InvalidJSONError is returned.
+func Minify(data []byte) ([]byte, error) {
+	return appendMinified(make([]byte, 0, len(data)), data)
+}
+
+// appendMinified appends data minified to dst, data being read by the Decoder's tokenizer.
+func appendMinified(dst, data []byte) ([]byte, error) {
+	start := len(dst)
+	dec := newDecoder(nil, 0)
+	dec.data = data
+	dec.length = len(data)
+	dst, err := dec.appendMinified(dst)
+	if err == nil && dec.walkSpace() != 0 {
+		err = dec.walkError(dec.data[dec.cursor])
+	}
+	dec.data = nil
+	dec.addToPool()
+	if err != nil {
+		return dst[:start], err
+	}
+	return dst, nil
+}
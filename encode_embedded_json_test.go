@@ -0,0 +1,195 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEmbeddedJSON struct {
+	id  int
+	raw EmbeddedJSON
+}
+
+func (t *testEmbeddedJSON) IsNil() bool {
+	return t == nil
+}
+
+func (t *testEmbeddedJSON) MarshalObject(enc *Encoder) {
+	enc.AddIntKey("id", t.id)
+	enc.AddEmbeddedJSONKey("raw", t.raw)
+}
+
+type testEmbeddedJSONSlice []EmbeddedJSON
+
+func (t testEmbeddedJSONSlice) MarshalArray(enc *Encoder) {
+	for _, e := range t {
+		enc.AddEmbeddedJSON(e)
+	}
+}
+
+func TestEncoderEmbeddedJSONKey(t *testing.T) {
+	v := &testEmbeddedJSON{
+		id:  1,
+		raw: EmbeddedJSON(`{"test": "hello world", "arr": [1, 2]}`),
+	}
+	r, err := MarshalObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"id":1,"raw":{"test": "hello world", "arr": [1, 2]}}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderEmbeddedJSONArray(t *testing.T) {
+	v := testEmbeddedJSONSlice{
+		EmbeddedJSON(`{"test":1}`),
+		EmbeddedJSON(`"string"`),
+	}
+	r, err := MarshalArray(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`[{"test":1},"string"]`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderEmbeddedJSONBytes(t *testing.T) {
+	// fragments rendered elsewhere, e.g. read from a cache
+	cached := []byte(`{"name":"a \"quoted\" name"}`)
+	items := [][]byte{[]byte(`1`), []byte(`"two"`)}
+	r, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddEmbeddedJSONKey("user", cached)
+		enc.AddArrayKey("items", EncodeArrayFunc(func(enc *Encoder) {
+			for _, item := range items {
+				enc.AddEmbeddedJSON(item)
+			}
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"user":{"name":"a \"quoted\" name"},"items":[1,"two"]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderEmbeddedJSONMinify(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetMinifyEmbedded(true)
+	err := enc.AddObject(&testEmbeddedJSON{
+		id: 1,
+		raw: EmbeddedJSON(`{
+			"test": "hello   world",
+			"escaped": "quote \" and \\",
+			"arr": [ 1, 2 ]
+		}`),
+	})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"id":1,"raw":{"test":"hello   world","escaped":"quote \" and \\","arr":[1,2]}}`,
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderEmbeddedJSONMinifyError(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetMinifyEmbedded(true)
+	enc.writeByte('{')
+	err := enc.AddEmbeddedJSONKey("raw", EmbeddedJSON(`{"test": "unterminated}`))
+	assert.NotNil(t, err, "Error should not be nil")
+	assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+	assert.Equal(t, `{`, string(enc.Bytes()), "buffer should be truncated back")
+}
+
+func TestMinify(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "basic-object",
+			json:     "{ \"a\" : 1 ,\n\t\"b\" : [ true , null ]\r\n}",
+			expected: `{"a":1,"b":[true,null]}`,
+		},
+		{
+			name:     "string-contents-preserved",
+			json:     `[ "  spaces  ", "tab	tab", "escaped \" quote" , "\\" ]`,
+			expected: `["  spaces  ","tab	tab","escaped \" quote","\\"]`,
+		},
+		{
+			name:     "already-minified",
+			json:     `{"a":{"b":"c"}}`,
+			expected: `{"a":{"b":"c"}}`,
+		},
+		{
+			name: "unterminated-string",
+			json: `{"a": "b}`,
+			err:  true,
+		},
+		{
+			name: "missing-colon",
+			json: `{"a" 1}`,
+			err:  true,
+		},
+		{
+			name: "unclosed-array",
+			json: `[1, 2`,
+			err:  true,
+		},
+		{
+			name: "trailing-comma",
+			json: `{"a":1,}`,
+			err:  true,
+		},
+		{
+			name: "trailing-data",
+			json: `{"a":1} {"b":2}`,
+			err:  true,
+		},
+		{
+			name: "invalid-literal",
+			json: `[tru]`,
+			err:  true,
+		},
+		{
+			name: "empty",
+			json: ` `,
+			err:  true,
+		},
+		{
+			name: "number-trailing-dot",
+			json: `{"a":1.}`,
+			err:  true,
+		},
+		{
+			name: "number-leading-zero",
+			json: `[01]`,
+			err:  true,
+		},
+		{
+			name: "number-exponent-without-digit",
+			json: `[1e+]`,
+			err:  true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r, err := Minify([]byte(testCase.json))
+			if testCase.err {
+				assert.NotNil(t, err, "err should not be nil")
+				assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+				return
+			}
+			assert.Nil(t, err, "err should be nil")
+			assert.Equal(t, testCase.expected, string(r), "Result of minify is different as the one expected")
+		})
+	}
+}
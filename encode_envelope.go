@@ -0,0 +1,31 @@
+package gojay
+
+// SetEnvelopeFields sets the keys and values written by WriteEnvelope.
+// Values are encoded as with AddInterfaceKey.
+//
+// The map is not copied, it must not be modified while the Encoder is in use.
+func (enc *Encoder) SetEnvelopeFields(fields map[string]interface{}) {
+	enc.envelope = fields
+}
+
+// WriteEnvelope adds the fields set with SetEnvelopeFields at the current position,
+// must be used inside an object as it will encode keys.
+//
+// Fields are written in the byte-wise order of their keys so that the output is deterministic,
+// they can be written before or after any other key of the object.
+func (enc *Encoder) WriteEnvelope() error {
+	if len(enc.envelope) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(enc.envelope))
+	for k := range enc.envelope {
+		keys = append(keys, k)
+	}
+	sortKeys(keys)
+	for _, k := range keys {
+		if err := enc.AddInterfaceKey(k, enc.envelope[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderWriteEnvelope(t *testing.T) {
+	fields := map[string]interface{}{
+		"request_id":  "abc",
+		"tenant_id":   42,
+		"server_time": 1520000000.5,
+	}
+	testCases := []struct {
+		name     string
+		obj      func(enc *Encoder)
+		expected string
+	}{
+		{
+			name: "envelope-first",
+			obj: func(enc *Encoder) {
+				enc.WriteEnvelope()
+				enc.AddStringKey("data", "value")
+			},
+			expected: `{"request_id":"abc","server_time":1520000000.5,"tenant_id":42,"data":"value"}`,
+		},
+		{
+			name: "envelope-last",
+			obj: func(enc *Encoder) {
+				enc.AddStringKey("data", "value")
+				enc.WriteEnvelope()
+			},
+			expected: `{"data":"value","request_id":"abc","server_time":1520000000.5,"tenant_id":42}`,
+		},
+		{
+			name: "envelope-in-nested-object",
+			obj: func(enc *Encoder) {
+				enc.AddObjectKey("meta", EncodeObjectFunc(func(enc *Encoder) {
+					enc.WriteEnvelope()
+				}))
+			},
+			expected: `{"meta":{"request_id":"abc","server_time":1520000000.5,"tenant_id":42}}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enc := NewEncoder()
+			defer enc.addToPool()
+			enc.SetEnvelopeFields(fields)
+			err := enc.AddObject(EncodeObjectFunc(testCase.obj))
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+		})
+	}
+}
+
+func TestEncoderWriteEnvelopeNoFields(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.WriteEnvelope()
+		enc.AddIntKey("a", 1)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"a":1}`, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+}
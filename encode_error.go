@@ -0,0 +1,32 @@
+package gojay
+
+// SetError records err as the error of the encoding in progress, so that a MarshalObject, MarshalArray
+// or MarshalMap method can abort the encoding when it fails, as the methods do not return an error.
+//
+// Only the first non nil error is kept. Once an error is set, the Add methods of objects, arrays and maps
+// return it without calling the Marshaler of the value, and the Marshal functions and the Encode methods
+// return it instead of the partial output. When streaming, what was flushed before the error stays written.
+func (enc *Encoder) SetError(err error) {
+	if enc.err == nil {
+		enc.err = err
+	}
+}
+
+// Err returns the error set by SetError, nil if none was set.
+func (enc *Encoder) Err() error {
+	return enc.err
+}
+
+// encoded returns the buffer of enc once a top level value is encoded, or the error set by SetError meanwhile.
+func (enc *Encoder) encoded() ([]byte, error) {
+	if enc.err != nil {
+		return nil, enc.err
+	}
+	if m := metrics(); m != nil {
+		m.Encoded(len(enc.buf))
+		if len(enc.buf) > poolConfig.EncoderBufferSize {
+			m.BufferGrow(PoolEncoder, cap(enc.buf))
+		}
+	}
+	return enc.buf, nil
+}
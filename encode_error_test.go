@@ -0,0 +1,70 @@
+package gojay
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errTestMarshal = errors.New("cannot marshal")
+
+func TestEncoderSetError(t *testing.T) {
+	var called bool
+	v := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("a", "b")
+		err := enc.AddObjectKey("nested", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+				enc.SetError(errTestMarshal)
+				enc.SetError(errors.New("ignored"))
+			}))
+		}))
+		assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+		err = enc.AddObjectKey("after", EncodeObjectFunc(func(enc *Encoder) {
+			called = true
+		}))
+		assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+	})
+	b, err := Marshal(v)
+	assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+	assert.Nil(t, b, "no output should be returned")
+	assert.False(t, called, "Marshaler should not be called once an error is set")
+
+	b, err = MarshalObject(v)
+	assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+	assert.Nil(t, b, "no output should be returned")
+
+	dst := []byte("prefix")
+	b, err = MarshalObjectAppend(dst, v)
+	assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+	assert.Equal(t, "prefix", string(b), "dst should be returned unchanged")
+
+	b, err = MarshalIndent(v, "", "  ")
+	assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+	assert.Nil(t, b, "no output should be returned")
+
+	_, err = Marshal([]interface{}{1, v})
+	assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+
+	_, err = Marshal(struct{ V EncodeObjectFunc }{v})
+	assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+}
+
+func TestEncoderSetErrorStream(t *testing.T) {
+	builder := &strings.Builder{}
+	enc := NewEncoderWriter(builder)
+	defer enc.addToPool()
+	err := enc.EncodeArray(EncodeArrayFunc(func(enc *Encoder) {
+		enc.AddInt(1)
+		enc.SetError(errTestMarshal)
+	}))
+	assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+	assert.Equal(t, errTestMarshal, enc.Err(), "Err should return the error set")
+
+	err = enc.EncodeArray(EncodeArrayFunc(func(enc *Encoder) {
+		enc.AddInt(2)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "[2]", builder.String(), "only the value encoded without error should be written")
+}
@@ -0,0 +1,70 @@
+package gojay
+
+// defaultEscapeTable is the escape table of the Encoder unless SetEscapeTable is called,
+// it flags the bytes which are not allowed as is in a JSON string: control characters, '"' and '\'.
+var defaultEscapeTable = DefaultEscapeTable()
+
+// DefaultEscapeTable returns the default escape table of the Encoder,
+// flagging the bytes which are not allowed as is in a JSON string: control characters, '"' and '\'.
+// It is meant to be used as the base of a custom table given to SetEscapeTable.
+func DefaultEscapeTable() [256]bool {
+	var t [256]bool
+	for c := 0; c < 0x20; c++ {
+		t[c] = true
+	}
+	t['"'] = true
+	t['\\'] = true
+	return t
+}
+
+// SetEscapeTable sets the table telling which bytes must be escaped in string values and keys,
+// a byte is escaped if its entry is true. Without a table set, DefaultEscapeTable is used.
+//
+// '"', '\' and '/' are escaped with a backslash, '\n', '\r', '\t', '\b' and '\f' with their short escape sequence,
+// other ASCII bytes as \u00XX. Flagging a byte of a multi-byte UTF-8 sequence escapes the whole rune as \uXXXX,
+// using a surrogate pair if needed.
+//
+// Beware that the table is trusted: an entry set to false for a byte of DefaultEscapeTable makes the
+// Encoder produce invalid JSON whenever a string contains that byte. It must only be done when the data
+// is guaranteed not to contain it.
+func (enc *Encoder) SetEscapeTable(table [256]bool) {
+	enc.escapeTable = &table
+	if enc.strCache != nil {
+		enc.strCache.reset()
+	}
+}
+
+// SetEscapeHTML sets whether '<', '>' and '&' must be escaped in strings as \u003c, \u003e and \u0026,
+// so that the output can be safely embedded in HTML, as encoding/json's Encoder.SetEscapeHTML does.
+// It updates the current escape table, see SetEscapeTable.
+func (enc *Encoder) SetEscapeHTML(on bool) {
+	t := *enc.getEscapeTable()
+	t['<'] = on
+	t['>'] = on
+	t['&'] = on
+	enc.SetEscapeTable(t)
+}
+
+// SetEscapeNonASCII sets whether runes above 0x7F must be escaped in strings and keys as \uXXXX sequences,
+// using a surrogate pair for runes above 0xFFFF, so that the output is pure ASCII.
+// Invalid UTF-8 bytes are written as \ufffd. It updates the current escape table, see SetEscapeTable.
+func (enc *Encoder) SetEscapeNonASCII(on bool) {
+	t := *enc.getEscapeTable()
+	for c := 0x80; c < 0x100; c++ {
+		t[c] = on
+	}
+	enc.SetEscapeTable(t)
+}
+
+// writeKey writes the key of an object field, without its quotes.
+// Keys are escaped as string values are, with the escape table of the Encoder, as PrecomputeKey escapes them.
+func (enc *Encoder) writeKey(key string) {
+	enc.writeStringEscape(key)
+}
+
+func (enc *Encoder) getEscapeTable() *[256]bool {
+	if enc.escapeTable == nil {
+		return &defaultEscapeTable
+	}
+	return enc.escapeTable
+}
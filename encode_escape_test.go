@@ -0,0 +1,142 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderEscapeTable(t *testing.T) {
+	htmlTable := DefaultEscapeTable()
+	htmlTable['<'] = true
+	htmlTable['>'] = true
+	htmlTable['&'] = true
+	unicodeTable := DefaultEscapeTable()
+	for c := 0x80; c < 0x100; c++ {
+		unicodeTable[c] = true
+	}
+	unsafeTable := DefaultEscapeTable()
+	unsafeTable['"'] = false
+	testCases := []struct {
+		name     string
+		table    *[256]bool
+		value    string
+		expected string
+	}{
+		{
+			name:     "default",
+			value:    "<a href=\"x\">é\n</a>",
+			expected: `"<a href=\"x\">é\n</a>"`,
+		},
+		{
+			name:     "html",
+			table:    &htmlTable,
+			value:    "<a>&é</a>",
+			expected: `"\u003ca\u003e\u0026é\u003c/a\u003e"`,
+		},
+		{
+			name:     "non-ascii",
+			table:    &unicodeTable,
+			value:    "aé漢😀\"",
+			expected: `"a\u00e9\u6f22\ud83d\ude00\""`,
+		},
+		{
+			name:     "non-ascii-invalid-utf8",
+			table:    &unicodeTable,
+			value:    "a\xffb",
+			expected: `"a\ufffdb"`,
+		},
+		{
+			name:     "unsafe-table",
+			table:    &unsafeTable,
+			value:    `quote"`,
+			expected: `"quote""`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enc := NewEncoder()
+			defer enc.addToPool()
+			if testCase.table != nil {
+				enc.SetEscapeTable(*testCase.table)
+			}
+			err := enc.AddString(testCase.value)
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+		})
+	}
+}
+
+func TestEncoderEscapeTableForwardSlash(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	table := DefaultEscapeTable()
+	table['<'] = true
+	enc.SetEscapeTable(table)
+	enc.SetEscapeForwardSlash(true)
+	enc.AddString("</a>")
+	assert.Equal(t, `"\u003c\/a>"`, string(enc.Bytes()), "forward slash escaping should be added to the custom table")
+	assert.False(t, defaultEscapeTable['/'], "default table should not be modified")
+}
+
+func TestEncoderEscapeHTML(t *testing.T) {
+	v := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("<b>", "<script>a && b</script>")
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddString("1 > 0")
+		}))
+	})
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetStringValueCache(8)
+	enc.SetEscapeHTML(true)
+	err := enc.AddObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"\u003cb\u003e":"\u003cscript\u003ea \u0026\u0026 b\u003c/script\u003e","arr":["1 \u003e 0"]}`,
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+
+	enc.buf = enc.buf[:0]
+	enc.hasValue = false
+	enc.SetEscapeHTML(false)
+	err = enc.AddObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"<b>":"<script>a && b</script>","arr":["1 > 0"]}`,
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderEscapeNonASCII(t *testing.T) {
+	v := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("clé", "café 😀")
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddString("\xff<")
+		}))
+	})
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetEscapeHTML(true)
+	enc.SetEscapeNonASCII(true)
+	err := enc.AddObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"cl\u00e9":"caf\u00e9 \ud83d\ude00","arr":["\ufffd\u003c"]}`,
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+
+	enc.buf = enc.buf[:0]
+	enc.hasValue = false
+	enc.SetEscapeNonASCII(false)
+	err = enc.AddObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		"{\"clé\":\"café 😀\",\"arr\":[\"\xff\\u003c\"]}",
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+}
@@ -0,0 +1,31 @@
+package gojay
+
+// estimateBufPool holds the scratch buffers EstimateSize encodes to
+var estimateBufPool = make(chan []byte, 16)
+
+// EstimateSize returns the number of bytes MarshalObject would return for v, without returning them.
+//
+// v is encoded to a scratch buffer borrowed from a pool and given back once the size is known,
+// so that no memory is allocated once the buffers have grown to the size of the encoded values.
+// A buffer grown above the MaxBufferSize of the PoolConfig is released instead.
+// The result can be used to check a size limit or to allocate a buffer of the exact size.
+func EstimateSize(v MarshalerObject) (int, error) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	select {
+	case enc.buf = <-estimateBufPool:
+	default:
+	}
+	enc.writeObject(v)
+	n, err := len(enc.buf), enc.err
+	if poolableBuffer(PoolEncoder, cap(enc.buf)) {
+		select {
+		case estimateBufPool <- enc.buf[:0]:
+		default:
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
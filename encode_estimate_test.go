@@ -0,0 +1,48 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateSize(t *testing.T) {
+	v := &testPatchUser{
+		name:     "John \"Jo\" Doe",
+		age:      30,
+		nickname: "é",
+		tags:     []string{"a", "b"},
+		address:  &testPatchAddress{"Paris", "75001"},
+	}
+	r, err := MarshalObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	for i := 0; i < 3; i++ {
+		n, err := EstimateSize(v)
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, len(r), n, "size should be the size of the encoded object")
+	}
+	n, err := EstimateSize(EncodeObjectFunc(func(enc *Encoder) {}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, 2, n, "size should be the size of an empty object")
+}
+
+func TestEstimateSizeMaxBufferSize(t *testing.T) {
+	defer SetPoolConfig(DefaultPoolConfig())
+	SetPoolConfig(PoolConfig{Size: 2, EncoderBufferSize: 16, DecoderBufferSize: 8, MaxBufferSize: 64})
+	for len(estimateBufPool) > 0 {
+		<-estimateBufPool
+	}
+	n, err := EstimateSize(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("s", strings.Repeat("a", 100))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, len(`{"s":""}`)+100, n, "size should be the size of the encoded object")
+	assert.Len(t, estimateBufPool, 0, "buffer larger than MaxBufferSize should not be pooled")
+	n, err = EstimateSize(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("s", "a")
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, len(`{"s":"a"}`), n, "size should be the size of the encoded object")
+	assert.Len(t, estimateBufPool, 1, "buffer within MaxBufferSize should be pooled")
+}
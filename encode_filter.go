@@ -0,0 +1,26 @@
+package gojay
+
+// SetKeyFilter sets a function telling whether a key must be encoded, for instance to only encode
+// the fields requested by a REST client. Every method adding a key skips the key and its value when keep
+// returns false, in nested objects too, so keep must accept the keys of the nested objects to encode.
+// A nil keep removes the filter.
+func (enc *Encoder) SetKeyFilter(keep func(key string) bool) {
+	enc.keyFilter = keep
+}
+
+// KeepKeys returns a key filter for SetKeyFilter accepting only the given keys.
+func KeepKeys(keys ...string) func(key string) bool {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+	return func(key string) bool {
+		_, ok := set[key]
+		return ok
+	}
+}
+
+// skipKey reports whether key must be skipped with its value, as told by the filter set with SetKeyFilter.
+func (enc *Encoder) skipKey(key string) bool {
+	return enc.keyFilter != nil && !enc.keyFilter(key)
+}
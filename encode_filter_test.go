@@ -0,0 +1,46 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderKeyFilter(t *testing.T) {
+	v := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddIntKey("id", 1)
+		enc.AddStringKey("name", "john")
+		enc.AddStringKey("email", "john@example.com")
+		enc.AddNullKey("deleted")
+		enc.AddObjectKey("profile", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntKey("age", 30)
+			enc.AddStringKey("name", "nested")
+		}))
+		enc.AddArrayKey("tags", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddString("a")
+		}))
+		enc.AddInterfaceKey("extra", struct {
+			ID   int    `json:"id"`
+			Note string `json:"note"`
+		}{2, "b"})
+	})
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetKeyFilter(KeepKeys("id", "name", "profile", "extra"))
+	err := enc.AddObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"id":1,"name":"john","profile":{"name":"nested"},"extra":{"id":2}}`,
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+
+	enc.Reset()
+	enc.SetKeyFilter(nil)
+	err = enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddIntKey("id", 1)
+		enc.AddBoolKey("ok", true)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"id":1,"ok":true}`, string(enc.Bytes()), "no key should be skipped without filter")
+}
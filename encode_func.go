@@ -0,0 +1,44 @@
+package gojay
+
+// EncodeObjectFunc adapts a function adding keys to an Encoder to a MarshalerObject,
+// so that an object can be encoded inline without declaring a type:
+//
+//	enc.AddObjectKey("user", gojay.EncodeObjectFunc(func(enc *gojay.Encoder) {
+//		enc.AddStringKey("name", name)
+//		enc.AddIntKey("age", age)
+//	}))
+//
+// A nil EncodeObjectFunc is encoded as null.
+type EncodeObjectFunc func(enc *Encoder)
+
+// MarshalObject implements MarshalerObject by calling f.
+func (f EncodeObjectFunc) MarshalObject(enc *Encoder) {
+	f(enc)
+}
+
+// IsNil implements MarshalerObject, it reports whether f is nil.
+func (f EncodeObjectFunc) IsNil() bool {
+	return f == nil
+}
+
+// EncodeArrayFunc adapts a function adding elements to an Encoder to a MarshalerArray,
+// so that an array can be encoded inline without declaring a type:
+//
+//	b, err := gojay.MarshalArray(gojay.EncodeArrayFunc(func(enc *gojay.Encoder) {
+//		for _, id := range ids {
+//			enc.AddInt(id)
+//		}
+//	}))
+//
+// A nil EncodeArrayFunc is encoded as null.
+type EncodeArrayFunc func(enc *Encoder)
+
+// MarshalArray implements MarshalerArray by calling f.
+func (f EncodeArrayFunc) MarshalArray(enc *Encoder) {
+	f(enc)
+}
+
+// IsNil implements NilMarshalerArray, it reports whether f is nil.
+func (f EncodeArrayFunc) IsNil() bool {
+	return f == nil
+}
@@ -0,0 +1,23 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderFuncs(t *testing.T) {
+	r, err := MarshalArray(EncodeArrayFunc(func(enc *Encoder) {
+		enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddStringKey("name", "a")
+			enc.AddArrayKey("ids", EncodeArrayFunc(func(enc *Encoder) {
+				enc.AddInt(1)
+				enc.AddInt(2)
+			}))
+		}))
+		enc.AddObject(EncodeObjectFunc(nil))
+		enc.AddArray(EncodeArrayFunc(nil))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `[{"name":"a","ids":[1,2]},null,null]`, string(r), "Result of marshalling is different as the one expected")
+}
@@ -0,0 +1,40 @@
+package gojay
+
+// SetFieldHook sets a function called for every key added to an object, in nested objects too,
+// so that values can be redacted, masked or audited in one place. A nil hook removes it.
+//
+// write adds the key and its value as the method called would. The hook can call write to keep the field,
+// not call it to omit the field, or add another value for key with the methods of the Encoder instead, such as:
+//
+//	enc.SetFieldHook(func(key string, write func()) {
+//		if key == "password" {
+//			enc.AddStringKey(key, "***")
+//			return
+//		}
+//		write()
+//	})
+//
+// The keys the hook adds in the object it is called for are not passed to the hook again.
+// Keys skipped by the filter of SetKeyFilter do not reach the hook.
+func (enc *Encoder) SetFieldHook(hook func(key string, write func())) {
+	enc.fieldHook = hook
+}
+
+// keyHooked reports whether key must go through the hook set with SetFieldHook,
+// it is not the case for the keys the hook itself adds.
+func (enc *Encoder) keyHooked(key string) bool {
+	return enc.fieldHook != nil && enc.hookDepth != enc.depth+1
+}
+
+// hookField calls the hook set with SetFieldHook for key, write adds the field and its error is returned.
+func (enc *Encoder) hookField(key string, write func() error) error {
+	prev := enc.hookDepth
+	// hookDepth is offset by one so that its zero value means no hook is running
+	enc.hookDepth = enc.depth + 1
+	var err error
+	enc.fieldHook(key, func() {
+		err = write()
+	})
+	enc.hookDepth = prev
+	return err
+}
@@ -0,0 +1,46 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderFieldHook(t *testing.T) {
+	var keys []string
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetKeyFilter(func(key string) bool { return key != "internal" })
+	enc.SetFieldHook(func(key string, write func()) {
+		keys = append(keys, key)
+		switch key {
+		case "password":
+			enc.AddStringKey(key, "***")
+		case "token":
+		default:
+			write()
+		}
+	})
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("name", "john")
+		enc.AddStringKey("password", "secret")
+		enc.AddStringKey("token", "abc")
+		enc.AddIntKey("internal", 1)
+		enc.AddObjectKey("account", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddStringKey("password", "other")
+			enc.AddIntKey("id", 2)
+		}))
+		enc.AddInterfaceKey("extra", map[string]string{"password": "x"})
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"name":"john","password":"***","account":{"password":"***","id":2},"extra":{"password":"***"}}`,
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+	assert.Equal(
+		t,
+		[]string{"name", "password", "token", "account", "password", "id", "extra", "password"},
+		keys,
+		"hook should be called once per key")
+}
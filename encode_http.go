@@ -0,0 +1,39 @@
+package gojay
+
+import (
+	"net/http"
+)
+
+const jsonContentType = "application/json; charset=utf-8"
+
+// EncodeResponse writes v to w as the JSON body of a response with the given status code.
+// The Content-Type header is set to application/json, unless the handler has already set one.
+//
+// v is streamed to w through the Encoder's buffer as it is encoded, it is never encoded whole to a []byte.
+// The status code is written along with the first bytes of the body, so that if encoding fails before
+// the buffer is first flushed, nothing is written and the handler can still reply with an error.
+// Once bytes have been flushed, an error leaves the response truncated.
+func EncodeResponse(w http.ResponseWriter, status int, v MarshalerObject) error {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", jsonContentType)
+	}
+	rw := &responseWriter{w: w, status: status}
+	enc := NewEncoderWriter(rw)
+	defer enc.addToPool()
+	return enc.EncodeObject(v)
+}
+
+// responseWriter delays the status code of a response until its body is written
+type responseWriter struct {
+	w           http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.wroteHeader = true
+		rw.w.WriteHeader(rw.status)
+	}
+	return rw.w.Write(b)
+}
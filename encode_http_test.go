@@ -0,0 +1,38 @@
+package gojay
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeResponse(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := EncodeResponse(w, http.StatusCreated, &testLineEvent{1, "a"})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, http.StatusCreated, w.Code, "status should be the one given")
+	assert.Equal(t, jsonContentType, w.Header().Get("Content-Type"), "Content-Type should be JSON")
+	assert.Equal(t, `{"id":1,"name":"a"}`, w.Body.String(), "Result of marshalling is different as the one expected")
+
+	w = httptest.NewRecorder()
+	w.Header().Set("Content-Type", "application/problem+json")
+	err = EncodeResponse(w, http.StatusOK, &testLineEvent{2, strings.Repeat("a", 2*streamFlushSize)})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"), "Content-Type should be kept")
+	assert.Equal(t, 2*streamFlushSize+len(`{"id":2,"name":""}`), w.Body.Len(), "whole body should be written")
+}
+
+func TestEncodeResponseError(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := EncodeResponse(w, http.StatusOK, EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddIntKey("id", 1)
+		enc.SetError(errTestMarshal)
+	}))
+	assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+	assert.False(t, w.Code != http.StatusOK || w.Body.Len() > 0, "nothing should be written")
+	http.Error(w, "failed", http.StatusInternalServerError)
+	assert.Equal(t, http.StatusInternalServerError, w.Code, "handler should still be able to reply an error")
+}
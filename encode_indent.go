@@ -0,0 +1,48 @@
+package gojay
+
+// SetIndent sets the Encoder to write each element of an object or array on a new line,
+// beginning with prefix followed by one copy of indent per level of nesting, as encoding/json's MarshalIndent does.
+// A space is written after the colon separating keys from values.
+//
+// Objects added with AddObjectKeyCompact and embedded JSON are not indented.
+func (enc *Encoder) SetIndent(prefix, indent string) {
+	enc.indented = true
+	enc.indentPrefix = prefix
+	enc.indent = indent
+}
+
+// MarshalIndent returns the JSON encoding of v as Marshal does, indented as set by SetIndent.
+// v must implement MarshalerObject or MarshalerArray.
+func MarshalIndent(v interface{}, prefix, indent string) ([]byte, error) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetIndent(prefix, indent)
+	switch vt := v.(type) {
+	case MarshalerObject:
+		enc.writeObject(vt)
+	case MarshalerArray:
+		enc.writeArray(vt)
+	default:
+		return nil, InvalidTypeError("Unknown type to Marshal")
+	}
+	return enc.encoded()
+}
+
+// writeIndent writes a new line followed by the indentation of depth levels of nesting.
+func (enc *Encoder) writeIndent(depth int) {
+	enc.writeByte('\n')
+	enc.writeString(enc.indentPrefix)
+	for i := 0; i < depth; i++ {
+		enc.writeString(enc.indent)
+	}
+}
+
+// writeObjKey writes b, the end of a key such as objKey, adding a space after the colon if the output is indented.
+func (enc *Encoder) writeObjKey(b []byte) {
+	if enc.indented && enc.compact == 0 {
+		enc.writeString(`": `)
+		enc.write(b[2:])
+		return
+	}
+	enc.write(b)
+}
@@ -0,0 +1,77 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalIndent(t *testing.T) {
+	v := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("name", "John")
+		enc.AddIntKey("age", 30)
+		enc.AddFloat32Key("score", 1.5)
+		enc.AddArrayKey("tags", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddString("a")
+			enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+				enc.AddBoolKey("ok", true)
+			}))
+		}))
+		enc.AddArrayKey("empty", EncodeArrayFunc(func(enc *Encoder) {}))
+		enc.AddObjectKey("address", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddNullKey("zip")
+		}))
+		enc.AddObjectKeyCompact("point", func(enc *Encoder) {
+			enc.AddIntKey("x", 1)
+			enc.AddIntKey("y", 2)
+		})
+	})
+	r, err := MarshalIndent(v, ">", "  ")
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		"{\n"+
+			">  \"name\": \"John\",\n"+
+			">  \"age\": 30,\n"+
+			">  \"score\": 1.5,\n"+
+			">  \"tags\": [\n"+
+			">    \"a\",\n"+
+			">    {\n"+
+			">      \"ok\": true\n"+
+			">    }\n"+
+			">  ],\n"+
+			">  \"empty\": [],\n"+
+			">  \"address\": {\n"+
+			">    \"zip\": null\n"+
+			">  },\n"+
+			">  \"point\": {\"x\":1,\"y\":2}\n"+
+			">}",
+		string(r),
+		"Result of marshalling is different as the one expected")
+
+	r, err = MarshalIndent(EncodeArrayFunc(func(enc *Encoder) {
+		enc.AddInt(1)
+		enc.AddInt(2)
+	}), "", "\t")
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "[\n\t1,\n\t2\n]", string(r), "Result of marshalling is different as the one expected")
+
+	_, err = MarshalIndent(1, "", "\t")
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+}
+
+func TestEncoderSetIndent(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetIndent("", " ")
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddEmbeddedJSONKey("raw", EmbeddedJSON(`{"a":1}`))
+		enc.AddObjectKey("empty", EncodeObjectFunc(func(enc *Encoder) {}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		"{\n \"raw\": {\"a\":1},\n \"empty\": {}\n}",
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+}
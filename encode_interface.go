@@ -1,52 +1,94 @@
 package gojay
 
+import (
+	"encoding"
+	"encoding/json"
+	"math/big"
+	"reflect"
+)
+
 // AddInterface adds an interface{} to be encoded, must be used inside a slice or array encoding (does not encode a key)
+// value is encoded according to its dynamic type as Marshal does, falling back to reflection
+// for the types without a dedicated Add method. A nil value is not encoded.
 func (enc *Encoder) AddInterface(value interface{}) error {
 	switch value.(type) {
 	case string:
 		return enc.AddString(value.(string))
 	case bool:
 		return enc.AddBool(value.(bool))
-	case MarshalerArray:
-		return enc.AddArray(value.(MarshalerArray))
 	case MarshalerObject:
 		return enc.AddObject(value.(MarshalerObject))
+	case MarshalerArray:
+		return enc.AddArray(value.(MarshalerArray))
+	case MarshalerMap:
+		return enc.AddMap(value.(MarshalerMap))
+	case map[string]interface{}:
+		return enc.AddMap(interfaceMap(value.(map[string]interface{})))
+	case []interface{}:
+		return enc.AddArray(interfaceSlice(value.([]interface{})))
 	case int:
 		return enc.AddInt(value.(int))
 	case int64:
 		return enc.AddInt(int(value.(int64)))
 	case int32:
 		return enc.AddInt(int(value.(int32)))
+	case int16:
+		return enc.AddInt(int(value.(int16)))
 	case int8:
 		return enc.AddInt(int(value.(int8)))
+	case uint:
+		return enc.AddUint64(uint64(value.(uint)))
+	case uintptr:
+		return enc.AddUint64(uint64(value.(uintptr)))
 	case uint64:
-		return enc.AddInt(int(value.(uint64)))
+		return enc.AddUint64(value.(uint64))
 	case uint32:
-		return enc.AddInt(int(value.(uint32)))
+		return enc.AddUint64(uint64(value.(uint32)))
 	case uint16:
-		return enc.AddInt(int(value.(uint16)))
+		return enc.AddUint64(uint64(value.(uint16)))
 	case uint8:
-		return enc.AddInt(int(value.(uint8)))
+		return enc.AddUint64(uint64(value.(uint8)))
 	case float64:
 		return enc.AddFloat(value.(float64))
 	case float32:
 		return enc.AddFloat(float64(value.(float32)))
+	case json.Number:
+		return enc.AddNumber(value.(json.Number))
+	case *big.Int:
+		return enc.AddBigInt(value.(*big.Int))
+	case *big.Float:
+		return enc.AddBigFloat(value.(*big.Float))
+	case json.Marshaler:
+		return enc.AddJSONMarshaler(value.(json.Marshaler))
+	case encoding.TextMarshaler:
+		return enc.AddTextMarshaler(value.(encoding.TextMarshaler))
 	}
-
-	return nil
+	if value == nil {
+		return nil
+	}
+	// no Marshaler interface implemented, encoding by reflection
+	return enc.addReflect(reflect.ValueOf(value))
 }
 
 // AddInterfaceKey adds an interface{} to be encoded, must be used inside an object as it will encode a key
+// value is encoded according to its dynamic type as Marshal does, falling back to reflection
+// for the types without a dedicated Add method. A nil value is not encoded.
 func (enc *Encoder) AddInterfaceKey(key string, value interface{}) error {
 	switch value.(type) {
 	case string:
 		return enc.AddStringKey(key, value.(string))
 	case bool:
 		return enc.AddBoolKey(key, value.(bool))
-	case MarshalerArray:
-		return enc.AddArrayKey(key, value.(MarshalerArray))
 	case MarshalerObject:
 		return enc.AddObjectKey(key, value.(MarshalerObject))
+	case MarshalerArray:
+		return enc.AddArrayKey(key, value.(MarshalerArray))
+	case MarshalerMap:
+		return enc.AddMapKey(key, value.(MarshalerMap))
+	case map[string]interface{}:
+		return enc.AddMapKey(key, interfaceMap(value.(map[string]interface{})))
+	case []interface{}:
+		return enc.AddArrayKey(key, interfaceSlice(value.([]interface{})))
 	case int:
 		return enc.AddIntKey(key, value.(int))
 	case int64:
@@ -57,19 +99,70 @@ func (enc *Encoder) AddInterfaceKey(key string, value interface{}) error {
 		return enc.AddIntKey(key, int(value.(int16)))
 	case int8:
 		return enc.AddIntKey(key, int(value.(int8)))
+	case uint:
+		return enc.AddUint64Key(key, uint64(value.(uint)))
+	case uintptr:
+		return enc.AddUint64Key(key, uint64(value.(uintptr)))
 	case uint64:
-		return enc.AddIntKey(key, int(value.(uint64)))
+		return enc.AddUint64Key(key, value.(uint64))
 	case uint32:
-		return enc.AddIntKey(key, int(value.(uint32)))
+		return enc.AddUint64Key(key, uint64(value.(uint32)))
 	case uint16:
-		return enc.AddIntKey(key, int(value.(uint16)))
+		return enc.AddUint64Key(key, uint64(value.(uint16)))
 	case uint8:
-		return enc.AddIntKey(key, int(value.(uint8)))
+		return enc.AddUint64Key(key, uint64(value.(uint8)))
 	case float64:
 		return enc.AddFloatKey(key, value.(float64))
 	case float32:
 		return enc.AddFloat32Key(key, value.(float32))
+	case json.Number:
+		return enc.AddNumberKey(key, value.(json.Number))
+	case *big.Int:
+		return enc.AddBigIntKey(key, value.(*big.Int))
+	case *big.Float:
+		return enc.AddBigFloatKey(key, value.(*big.Float))
+	case json.Marshaler:
+		return enc.AddJSONMarshalerKey(key, value.(json.Marshaler))
+	case encoding.TextMarshaler:
+		return enc.AddTextMarshalerKey(key, value.(encoding.TextMarshaler))
+	}
+	if value == nil {
+		return nil
+	}
+	// no Marshaler interface implemented, encoding by reflection
+	return enc.addReflectKey(key, reflect.ValueOf(value), false)
+}
+
+// interfaceMap encodes a map[string]interface{}, its values are encoded as with AddInterfaceKey and nil ones as null.
+// The encoding stops at the first value failing, its error is set with SetError.
+type interfaceMap map[string]interface{}
+
+func (m interfaceMap) MarshalMap(enc *Encoder) {
+	for k, v := range m {
+		if v == nil {
+			enc.AddNullKey(k)
+			continue
+		}
+		if err := enc.AddInterfaceKey(k, v); err != nil {
+			enc.SetError(err)
+			return
+		}
 	}
+}
 
-	return nil
+// interfaceSlice encodes a []interface{}, its elements are encoded as with AddInterface and nil ones as null.
+// The encoding stops at the first element failing, its error is set with SetError.
+type interfaceSlice []interface{}
+
+func (s interfaceSlice) MarshalArray(enc *Encoder) {
+	for _, v := range s {
+		if v == nil {
+			enc.AddNull()
+			continue
+		}
+		if err := enc.AddInterface(v); err != nil {
+			enc.SetError(err)
+			return
+		}
+	}
 }
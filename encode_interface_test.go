@@ -0,0 +1,96 @@
+package gojay
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderInterfaceGeneric(t *testing.T) {
+	data := `{"a":1,"b":"str","c":[true,null,{"d":[1.5,"e"]}],"f":null,"g":{}}`
+	var v map[string]interface{}
+	err := json.Unmarshal([]byte(data), &v)
+	assert.Nil(t, err, "Error should be nil")
+
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetSortMapKeys(true)
+	err = enc.AddInterface(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, data, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+
+	r, err := Marshal(map[string]interface{}{"a": []interface{}{1, "2"}})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"a":[1,"2"]}`, string(r), "Result of marshalling is different as the one expected")
+
+	r, err = Marshal([]interface{}{map[string]interface{}{"a": nil}, []interface{}{}, nil})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `[{"a":null},[],null]`, string(r), "Result of marshalling is different as the one expected")
+
+	r, err = MarshalAppend([]byte(`x`), []interface{}{1})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `x[1]`, string(r), "Result of marshalling is different as the one expected")
+
+	r, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddInterfaceKey("m", map[string]interface{}{"a": 1})
+		enc.AddInterfaceKey("s", []interface{}{"b"})
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"m":{"a":1},"s":["b"]}`, string(r), "Result of marshalling is different as the one expected")
+}
+
+type testInterfaceStruct struct {
+	Name string `json:"name"`
+	Age  int    `json:"age,omitempty"`
+}
+
+func TestEncoderInterfaceDispatch(t *testing.T) {
+	r, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddInterfaceKey("int16", int16(-3))
+		enc.AddInterfaceKey("uint32", uint32(math.MaxUint32))
+		enc.AddInterfaceKey("struct", testInterfaceStruct{Name: "a"})
+		enc.AddInterfaceKey("bytes", []byte("hi"))
+		enc.AddInterfaceKey("ints", []int{1, 2})
+		enc.AddInterfaceKey("nil", nil)
+		enc.AddInterfaceKey("obj", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntKey("x", 1)
+		}))
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddInterface(int16(4))
+			enc.AddInterface(uint32(math.MaxUint32))
+			enc.AddInterface(uint16(math.MaxUint16))
+			enc.AddInterface(uint8(math.MaxUint8))
+			enc.AddInterface(&testInterfaceStruct{Name: "b", Age: 2})
+			enc.AddInterface(nil)
+			enc.AddInterface(map[string]int{"k": 1})
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"int16":-3,"uint32":4294967295,"struct":{"name":"a"},"bytes":"aGk=","ints":[1,2],"obj":{"x":1},`+
+			`"arr":[4,4294967295,65535,255,{"name":"b","age":2},{"k":1}]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.writeByte('{')
+	err = enc.AddInterfaceKey("ch", make(chan int))
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	enc.AddIntKey("ok", 1)
+	assert.Equal(t, `{"ok":1`, string(enc.Bytes()), "failed key should be removed")
+	assert.IsType(t, InvalidTypeError(""), enc.Err(), "error should be set on the encoder")
+
+	_, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddInterfaceKey("c", make(chan int))
+		enc.AddIntKey("ok", 1)
+	}))
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	_, err = Marshal(map[string]interface{}{"m": map[string]interface{}{"c": func() {}}})
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	_, err = Marshal([]interface{}{1, []interface{}{complex(1, 2)}})
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+}
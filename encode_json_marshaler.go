@@ -0,0 +1,61 @@
+package gojay
+
+import "encoding/json"
+
+// AddJSONMarshaler adds a json.Marshaler to be encoded, must be used inside a slice or array encoding (does not encode a key)
+// The output of its MarshalJSON method is embedded stripped of its insignificant whitespace, as encoding/json does.
+// If v is nil, null is written. If MarshalJSON fails, nothing is written and its error is returned,
+// it is also set with SetError.
+func (enc *Encoder) AddJSONMarshaler(v json.Marshaler) error {
+	raw, err := marshalJSON(v)
+	if err != nil {
+		enc.SetError(err)
+		return err
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.write(raw)
+	enc.record(embeddedKind(raw), start)
+	return nil
+}
+
+// AddJSONMarshalerKey adds a json.Marshaler to be encoded, must be used inside an object as it will encode a key
+// The output of its MarshalJSON method is embedded stripped of its insignificant whitespace, as encoding/json does.
+// If v is nil, null is written. If MarshalJSON fails, nothing is written and its error is returned,
+// it is also set with SetError.
+func (enc *Encoder) AddJSONMarshalerKey(key string, v json.Marshaler) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddJSONMarshalerKey(key, v) })
+	}
+	raw, err := marshalJSON(v)
+	if err != nil {
+		enc.SetError(err)
+		return err
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	enc.write(raw)
+	enc.record(embeddedKind(raw), start)
+	return nil
+}
+
+// marshalJSON returns the minified output of v's MarshalJSON method, null if v is nil.
+func marshalJSON(v json.Marshaler) ([]byte, error) {
+	if isNilValue(v) {
+		return []byte("null"), nil
+	}
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	if embeddedKind(raw) == 0 {
+		return nil, InvalidJSONError("Invalid JSON returned by MarshalJSON")
+	}
+	return Minify(raw)
+}
@@ -0,0 +1,82 @@
+package gojay
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testUUID [4]byte
+
+func (u testUUID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + string(u[:]) + `"`), nil
+}
+
+type testDecimal struct {
+	units int
+}
+
+func (d *testDecimal) MarshalJSON() ([]byte, error) {
+	if d.units < 0 {
+		return nil, errors.New("negative decimal")
+	}
+	return []byte(` { "units" : 1 } `), nil
+}
+
+type testBadJSONMarshaler struct{}
+
+func (testBadJSONMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(` `), nil
+}
+
+func TestEncoderJSONMarshaler(t *testing.T) {
+	r, err := Marshal(testUUID{'a', 'b', 'c', 'd'})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `"abcd"`, string(r), "Result of marshalling is different as the one expected")
+
+	var nilDecimal *testDecimal
+	r, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddInterfaceKey("id", testUUID{'a', 'b', 'c', 'd'})
+		enc.AddJSONMarshalerKey("decimal", &testDecimal{1})
+		enc.AddJSONMarshalerKey("nil", nilDecimal)
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddJSONMarshaler(nil)
+			enc.AddInterface(&testDecimal{1})
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"id":"abcd","decimal":{"units":1},"nil":null,"arr":[null,{"units":1}]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+
+	// fields encoded by reflection
+	r, err = Marshal(struct {
+		ID      testUUID     `json:"id"`
+		Decimal *testDecimal `json:"decimal"`
+	}{testUUID{'e', 'f', 'g', 'h'}, &testDecimal{1}})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"id":"efgh","decimal":{"units":1}}`, string(r), "Result of marshalling is different as the one expected")
+}
+
+func TestEncoderJSONMarshalerErrors(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.writeByte('{')
+	err := enc.AddJSONMarshalerKey("decimal", &testDecimal{-1})
+	assert.Equal(t, "negative decimal", err.Error(), "err should be the one returned by MarshalJSON")
+	err = enc.AddJSONMarshalerKey("bad", testBadJSONMarshaler{})
+	assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+	assert.Equal(t, `{`, string(enc.Bytes()), "nothing should be written")
+	assert.Equal(t, "negative decimal", enc.Err().Error(), "first error should be set on the encoder")
+
+	_, err = Marshal(struct{ D *testDecimal }{&testDecimal{-1}})
+	assert.NotNil(t, err, "Error should not be nil")
+	_, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddJSONMarshalerKey("decimal", &testDecimal{-1})
+		enc.AddIntKey("ok", 1)
+	}))
+	assert.Equal(t, "negative decimal", err.Error(), "err should be the one returned by MarshalJSON")
+}
@@ -0,0 +1,99 @@
+package gojay
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AddNumber adds a json.Number to be encoded, must be used inside a slice or array encoding (does not encode a key)
+// The literal is written verbatim, so numbers of any precision round-trip unchanged. An empty json.Number is written as 0.
+// If n is not a valid JSON number, nothing is written and an InvalidJSONError is returned, it is also set with SetError.
+func (enc *Encoder) AddNumber(n json.Number) error {
+	s, err := numberLiteral(n)
+	if err != nil {
+		enc.SetError(err)
+		return err
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeString(s)
+	enc.record(KindNumber, start)
+	return nil
+}
+
+// AddNumberKey adds a json.Number to be encoded, must be used inside an object as it will encode a key
+// The literal is written verbatim, so numbers of any precision round-trip unchanged. An empty json.Number is written as 0.
+// If n is not a valid JSON number, nothing is written and an InvalidJSONError is returned, it is also set with SetError.
+func (enc *Encoder) AddNumberKey(key string, n json.Number) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddNumberKey(key, n) })
+	}
+	s, err := numberLiteral(n)
+	if err != nil {
+		enc.SetError(err)
+		return err
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	enc.writeString(s)
+	enc.record(KindNumber, start)
+	return nil
+}
+
+// numberLiteral returns the literal to write for n, as encoding/json does an empty json.Number is 0.
+func numberLiteral(n json.Number) (string, error) {
+	s := string(n)
+	if s == "" {
+		return "0", nil
+	}
+	if !isValidNumber(s) {
+		return "", InvalidJSONError(fmt.Sprintf("Invalid json.Number %q", s))
+	}
+	return s, nil
+}
+
+// isValidNumber reports whether s is a valid JSON number literal, as defined by RFC 8259.
+func isValidNumber(s string) bool {
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	// integer part, no leading zero
+	switch {
+	case i < len(s) && s[i] == '0':
+		i++
+	case i < len(s) && s[i] >= '1' && s[i] <= '9':
+		for i++; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+		}
+	default:
+		return false
+	}
+	// fraction
+	if i < len(s) && s[i] == '.' {
+		i++
+		if i >= len(s) || s[i] < '0' || s[i] > '9' {
+			return false
+		}
+		for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+		}
+	}
+	// exponent
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		if i >= len(s) || s[i] < '0' || s[i] > '9' {
+			return false
+		}
+		for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+		}
+	}
+	return i == len(s)
+}
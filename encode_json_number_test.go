@@ -0,0 +1,52 @@
+package gojay
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderJSONNumber(t *testing.T) {
+	r, err := Marshal(json.Number("123456789012345678901234567890.000000000000000000001"))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `123456789012345678901234567890.000000000000000000001`, string(r), "Result of marshalling is different as the one expected")
+
+	r, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddNumberKey("exp", json.Number("-1.5E+10"))
+		enc.AddNumberKey("empty", json.Number(""))
+		enc.AddInterfaceKey("iface", json.Number("0.25"))
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddNumber(json.Number("0"))
+			enc.AddInterface(json.Number("1e-3"))
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"exp":-1.5E+10,"empty":0,"iface":0.25,"arr":[0,1e-3]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderJSONNumberInvalid(t *testing.T) {
+	for _, n := range []string{"01", "1.", ".5", "-", "1e", "1e+", "+1", "0x10", "NaN", "1 ", `1,"a":2`} {
+		t.Run(n, func(t *testing.T) {
+			enc := NewEncoder()
+			defer enc.addToPool()
+			enc.writeByte('{')
+			err := enc.AddNumberKey("n", json.Number(n))
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+			assert.Equal(t, `{`, string(enc.Bytes()), "nothing should be written")
+			assert.IsType(t, InvalidJSONError(""), enc.Err(), "error should be set on the encoder")
+		})
+	}
+	_, err := Marshal(json.Number("abc"))
+	assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+	_, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddNumberKey("n", json.Number("abc"))
+		enc.AddIntKey("ok", 1)
+	}))
+	assert.IsType(t, InvalidJSONError(""), err, "err should be of type InvalidJSONError")
+}
@@ -0,0 +1,148 @@
+package gojay
+
+import "strconv"
+
+// Key is an object key escaped, quoted and followed by a colon once and for all by PrecomputeKey,
+// so that the AddXPreparedKey methods write it as is instead of escaping it on every encoding.
+// A Key is immutable and can be shared by encoders, it is meant to be stored in a package level variable:
+//
+//	var keyUserName = gojay.PrecomputeKey("user_name")
+//
+//	func (u *User) MarshalObject(enc *gojay.Encoder) {
+//		enc.AddStringPreparedKey(keyUserName, u.name)
+//	}
+type Key struct {
+	name string
+	raw  []byte
+}
+
+// PrecomputeKey returns the Key for name, escaped with the default escape table.
+func PrecomputeKey(name string) Key {
+	enc := &Encoder{}
+	enc.writeByte('"')
+	enc.writeStringEscape(name)
+	enc.writeString(`":`)
+	return Key{name: name, raw: enc.buf}
+}
+
+// String returns the name of the key, unescaped.
+func (k Key) String() string {
+	return k.name
+}
+
+// writePreparedKey writes the bytes of key and reports whether it could, the AddXKey method must be used otherwise.
+// The bytes are only written as is when they are what writing the key would produce: with the default escape table,
+// without indentation and when no key filter nor field hook can intercept the key.
+func (enc *Encoder) writePreparedKey(key Key) bool {
+	if key.raw == nil || enc.escapeTable != nil || enc.keyFilter != nil || enc.fieldHook != nil ||
+		(enc.indented && enc.compact == 0) {
+		return false
+	}
+	enc.writeSep()
+	enc.write(key.raw)
+	return true
+}
+
+// AddStringPreparedKey adds a string to be encoded as AddStringKey does, with a key built by PrecomputeKey
+func (enc *Encoder) AddStringPreparedKey(key Key, value string) error {
+	start := enc.offset()
+	if !enc.writePreparedKey(key) {
+		return enc.AddStringKey(key.name, value)
+	}
+	enc.writeByte('"')
+	enc.writeStringValue(value)
+	enc.writeByte('"')
+	enc.record(KindString, start)
+	return nil
+}
+
+// AddIntPreparedKey adds an int to be encoded as AddIntKey does, with a key built by PrecomputeKey
+func (enc *Encoder) AddIntPreparedKey(key Key, value int) error {
+	start := enc.offset()
+	if !enc.writePreparedKey(key) {
+		return enc.AddIntKey(key.name, value)
+	}
+	enc.buf = strconv.AppendInt(enc.buf, int64(value), 10)
+	enc.record(KindNumber, start)
+	return nil
+}
+
+// AddFloatPreparedKey adds a float64 to be encoded as AddFloatKey does, with a key built by PrecomputeKey
+func (enc *Encoder) AddFloatPreparedKey(key Key, value float64) error {
+	if err := enc.checkFloat(value); err != nil {
+		return err
+	}
+	if enc.floatNull(value) {
+		return enc.AddNullPreparedKey(key)
+	}
+	start := enc.offset()
+	if !enc.writePreparedKey(key) {
+		return enc.AddFloatKey(key.name, value)
+	}
+	k := enc.writeFloat(value, 64)
+	enc.record(k, start)
+	return nil
+}
+
+// AddBoolPreparedKey adds a bool to be encoded as AddBoolKey does, with a key built by PrecomputeKey
+func (enc *Encoder) AddBoolPreparedKey(key Key, value bool) error {
+	start := enc.offset()
+	if !enc.writePreparedKey(key) {
+		return enc.AddBoolKey(key.name, value)
+	}
+	enc.buf = strconv.AppendBool(enc.buf, value)
+	enc.record(KindBool, start)
+	return nil
+}
+
+// AddNullPreparedKey adds a null to be encoded as AddNullKey does, with a key built by PrecomputeKey
+func (enc *Encoder) AddNullPreparedKey(key Key) error {
+	if enc.stripNulls {
+		return enc.AddNullKey(key.name)
+	}
+	start := enc.offset()
+	if !enc.writePreparedKey(key) {
+		return enc.AddNullKey(key.name)
+	}
+	enc.writeString("null")
+	enc.record(KindNull, start)
+	return nil
+}
+
+// AddObjectPreparedKey adds a struct to be encoded as AddObjectKey does, with a key built by PrecomputeKey
+func (enc *Encoder) AddObjectPreparedKey(key Key, value MarshalerObject) error {
+	if value == nil || value.IsNil() {
+		return enc.AddNullPreparedKey(key)
+	}
+	if enc.err != nil {
+		return enc.err
+	}
+	start := enc.offset()
+	if !enc.writePreparedKey(key) {
+		return enc.AddObjectKey(key.name, value)
+	}
+	enc.writeOpen('{')
+	value.MarshalObject(enc)
+	enc.writeClose('}')
+	enc.record(KindObject, start)
+	return enc.err
+}
+
+// AddArrayPreparedKey adds an array or slice to be encoded as AddArrayKey does, with a key built by PrecomputeKey
+func (enc *Encoder) AddArrayPreparedKey(key Key, value MarshalerArray) error {
+	if isNilArray(value) {
+		return enc.AddNullPreparedKey(key)
+	}
+	if enc.err != nil {
+		return enc.err
+	}
+	start := enc.offset()
+	if !enc.writePreparedKey(key) {
+		return enc.AddArrayKey(key.name, value)
+	}
+	enc.writeOpen('[')
+	value.MarshalArray(enc)
+	enc.writeClose(']')
+	enc.record(KindArray, start)
+	return enc.err
+}
@@ -0,0 +1,100 @@
+package gojay
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testKeyName  = PrecomputeKey("user_name")
+	testKeyQuote = PrecomputeKey(`a"b`)
+	testKeyAge   = PrecomputeKey("age")
+	testKeyScore = PrecomputeKey("score")
+	testKeyOk    = PrecomputeKey("ok")
+	testKeyNone  = PrecomputeKey("none")
+	testKeySub   = PrecomputeKey("sub")
+	testKeyArr   = PrecomputeKey("arr")
+)
+
+type testPreparedKeys struct{}
+
+func (t *testPreparedKeys) MarshalObject(enc *Encoder) {
+	enc.AddStringPreparedKey(testKeyName, "<a>")
+	enc.AddIntPreparedKey(testKeyQuote, 1)
+	enc.AddIntPreparedKey(testKeyAge, 42)
+	enc.AddFloatPreparedKey(testKeyScore, 1.5)
+	enc.AddBoolPreparedKey(testKeyOk, true)
+	enc.AddNullPreparedKey(testKeyNone)
+	enc.AddObjectPreparedKey(testKeySub, EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddIntPreparedKey(testKeyAge, 1)
+	}))
+	enc.AddArrayPreparedKey(testKeyArr, EncodeArrayFunc(func(enc *Encoder) {
+		enc.AddInt(1)
+	}))
+}
+
+func (t *testPreparedKeys) IsNil() bool {
+	return t == nil
+}
+
+func TestEncoderPreparedKeys(t *testing.T) {
+	assert.Equal(t, `"user_name":`, string(testKeyName.raw), "key should be quoted and followed by a colon")
+	assert.Equal(t, `a"b`, testKeyQuote.String(), "String should return the name of the key")
+	r, err := MarshalObject(&testPreparedKeys{})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"user_name":"<a>","a\"b":1,"age":42,"score":1.5,"ok":true,"none":null,"sub":{"age":1},"arr":[1]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderPreparedKeysEscaping(t *testing.T) {
+	for _, name := range []string{`a"b`, `c\d`, "e\nf", "</g>"} {
+		prepared, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntPreparedKey(PrecomputeKey(name), 1)
+		}))
+		assert.Nil(t, err, "Error should be nil")
+		r, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntKey(name, 1)
+		}))
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, string(r), string(prepared), "prepared keys should be escaped as keys added by name")
+		var v map[string]int
+		err = json.Unmarshal(r, &v)
+		assert.Nil(t, err, "keys should be escaped to valid JSON")
+		assert.Equal(t, map[string]int{name: 1}, v, "keys should round-trip")
+	}
+}
+
+func TestEncoderPreparedKeysFallback(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetKeyFilter(KeepKeys("user_name", "sub", "age"))
+	enc.SetStripNulls(true)
+	enc.SetIndent("", " ")
+	err := enc.AddObject(&testPreparedKeys{})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		"{\n \"user_name\": \"<a>\",\n \"age\": 42,\n \"sub\": {\n  \"age\": 1\n }\n}",
+		string(enc.Bytes()),
+		"prepared keys should go through the key filter and indentation")
+
+	var keys []string
+	enc = NewEncoder()
+	defer enc.addToPool()
+	enc.SetFieldHook(func(key string, write func()) {
+		keys = append(keys, key)
+		write()
+	})
+	err = enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddIntPreparedKey(testKeyAge, 1)
+		enc.AddObjectPreparedKey(testKeySub, nil)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"age":1,"sub":null}`, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+	assert.Equal(t, []string{"age", "sub"}, keys, "prepared keys should go through the field hook")
+}
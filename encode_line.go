@@ -0,0 +1,49 @@
+package gojay
+
+import "io"
+
+// A LineEncoder writes JSON Lines (newline delimited JSON) to an output stream, one compact value per line.
+//
+// Lines are buffered and flushed to the io.Writer once the buffer holds streamFlushSize bytes,
+// always between two lines, so that the io.Writer only ever receives whole lines.
+// Flush or Close must be called once the last line is encoded.
+// The settings of the embedded Encoder apply to every line, except indentation which must not be set.
+type LineEncoder struct {
+	*Encoder
+}
+
+// NewLineEncoder returns a LineEncoder writing to w, its Encoder is borrowed from the pool.
+func NewLineEncoder(w io.Writer) *LineEncoder {
+	return &LineEncoder{NewEncoderWriter(w)}
+}
+
+// EncodeLine writes the JSON encoding of v followed by a new line.
+//
+// If encoding v fails, for instance because its MarshalObject method called SetError,
+// nothing is written for v and the error is returned. A nil v is written as null.
+func (enc *LineEncoder) EncodeLine(v MarshalerObject) error {
+	if enc.w == nil {
+		return NoWriterError("No writer given to Encoder")
+	}
+	mark := len(enc.buf)
+	enc.hasValue = false
+	enc.err = nil
+	enc.writeObject(v)
+	if enc.err != nil {
+		enc.buf = enc.buf[:mark]
+		return enc.err
+	}
+	enc.writeByte('\n')
+	if len(enc.buf) >= streamFlushSize {
+		return enc.Flush()
+	}
+	return nil
+}
+
+// Close flushes the lines buffered and gives the Encoder back to the pool,
+// the LineEncoder must not be used afterwards.
+func (enc *LineEncoder) Close() error {
+	err := enc.Flush()
+	enc.Release()
+	return err
+}
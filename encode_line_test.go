@@ -0,0 +1,80 @@
+package gojay
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLineEvent struct {
+	id   int
+	name string
+}
+
+func (e *testLineEvent) MarshalObject(enc *Encoder) {
+	enc.AddIntKey("id", e.id)
+	enc.AddStringKey("name", e.name)
+}
+
+func (e *testLineEvent) IsNil() bool {
+	return e == nil
+}
+
+func TestLineEncoder(t *testing.T) {
+	builder := &strings.Builder{}
+	enc := NewLineEncoder(builder)
+	err := enc.EncodeLine(&testLineEvent{1, "a"})
+	assert.Nil(t, err, "Error should be nil")
+	err = enc.EncodeLine(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddIntKey("id", 2)
+		enc.SetError(errTestMarshal)
+	}))
+	assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+	err = enc.EncodeLine(&testLineEvent{3, "c"})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "", builder.String(), "lines should be buffered")
+	err = enc.Close()
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "{\"id\":1,\"name\":\"a\"}\n{\"id\":3,\"name\":\"c\"}\n", builder.String(), "Result of marshalling is different as the one expected")
+}
+
+// testLineWriter records each call to Write
+type testLineWriter struct {
+	writes [][]byte
+}
+
+func (w *testLineWriter) Write(b []byte) (int, error) {
+	w.writes = append(w.writes, append([]byte(nil), b...))
+	return len(b), nil
+}
+
+func TestLineEncoderFlush(t *testing.T) {
+	w := &testLineWriter{}
+	enc := NewLineEncoder(w)
+	defer enc.Release()
+	for i := 0; i < 1000; i++ {
+		err := enc.EncodeLine(&testLineEvent{i, strings.Repeat("x", 20)})
+		assert.Nil(t, err, "Error should be nil")
+	}
+	err := enc.Flush()
+	assert.Nil(t, err, "Error should be nil")
+	assert.True(t, len(w.writes) > 1, "lines should be flushed as the buffer fills")
+	n := 0
+	for _, b := range w.writes {
+		assert.True(t, strings.HasSuffix(string(b), "\n"), "only whole lines should be written")
+		for _, line := range strings.Split(strings.TrimSuffix(string(b), "\n"), "\n") {
+			assert.Equal(t, `{"id":`+strconv.Itoa(n)+`,"name":"`+strings.Repeat("x", 20)+`"}`, line, "line should be the encoding of one event")
+			n++
+		}
+	}
+	assert.Equal(t, 1000, n, "every line should be written")
+}
+
+func TestLineEncoderNoWriter(t *testing.T) {
+	enc := &LineEncoder{NewEncoder()}
+	defer enc.Release()
+	err := enc.EncodeLine(&testLineEvent{1, "a"})
+	assert.IsType(t, NoWriterError(""), err, "err should be of type NoWriterError")
+}
@@ -0,0 +1,78 @@
+package gojay
+
+import "sort"
+
+// SetSortMapKeys sets whether map keys must be sorted when encoding maps,
+// that is MarshalerMap values added with AddMap, AddMapKey or passed to Marshal.
+// Entries are sorted once the map is closed, whatever the order MarshalMap added them in.
+//
+// Keys are sorted in byte-wise lexical order of their UTF-8 encoding, the order does not depend
+// on the locale nor on the Go version, for example "B" sorts before "a" and "a" before "é".
+func (enc *Encoder) SetSortMapKeys(sort bool) {
+	enc.sortMapKeys = sort
+}
+
+// sortKeys sorts keys in byte-wise lexical order.
+func sortKeys(keys []string) {
+	// comparison of strings in Go is a byte-wise comparison
+	sort.Strings(keys)
+}
+
+// MarshalerMap is the interface to implement for a map to be encoded,
+// MarshalMap must add each entry of the map with the methods encoding a key, such as AddStringKey.
+type MarshalerMap interface {
+	MarshalMap(enc *Encoder)
+}
+
+// AddMap adds a map to be encoded, must be used inside a slice or array encoding (does not encode a key)
+// value must implement MarshalerMap
+func (enc *Encoder) AddMap(value MarshalerMap) error {
+	if value == nil {
+		return nil
+	}
+	if enc.err != nil {
+		return enc.err
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('{')
+	enc.enterMap()
+	value.MarshalMap(enc)
+	enc.writeClose('}')
+	enc.record(KindObject, start)
+	return enc.err
+}
+
+// AddMapKey adds a map to be encoded, must be used inside an object as it will encode a key
+// value must implement MarshalerMap
+func (enc *Encoder) AddMapKey(key string, value MarshalerMap) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddMapKey(key, value) })
+	}
+	if value == nil {
+		return nil
+	}
+	if enc.err != nil {
+		return enc.err
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKeyObj)
+	enc.enterMap()
+	value.MarshalMap(enc)
+	enc.writeClose('}')
+	enc.record(KindObject, start)
+	return enc.err
+}
+
+// enterMap records that a map has just been opened, its entries are sorted if SetSortMapKeys was set.
+func (enc *Encoder) enterMap() {
+	enc.sortMap = enc.sortMapKeys
+	enc.enter()
+	enc.sortMap = false
+}
@@ -0,0 +1,106 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderSetSortMapKeys(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetSortMapKeys(true)
+	assert.True(t, enc.sortMapKeys, "sortMapKeys should be true")
+}
+
+func TestEncoderSortKeysByteOrder(t *testing.T) {
+	// under unicode collation lowercase and uppercase letters would be interleaved
+	// and accented letters sorted next to their base letter
+	keys := []string{"b", "é", "B", "a", "Z", "A", "z", "ab", "_", "1"}
+	sortKeys(keys)
+	assert.Equal(
+		t,
+		`1,A,B,Z,_,a,ab,b,z,é`,
+		strings.Join(keys, ","),
+		"keys should be sorted in byte-wise order",
+	)
+}
+
+type testMap map[string]int
+
+func (m testMap) MarshalMap(enc *Encoder) {
+	for k, v := range m {
+		enc.AddIntKey(k, v)
+	}
+}
+
+type testMapObjects map[string]*testObject
+
+func (m testMapObjects) MarshalMap(enc *Encoder) {
+	for k, v := range m {
+		enc.AddObjectKey(k, v)
+	}
+}
+
+func TestEncoderMap(t *testing.T) {
+	r, err := Marshal(testMap{"a": 1})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"a":1}`, string(r), "Result of marshalling is different as the one expected")
+
+	r, err = Marshal(testMap(nil))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{}`, string(r), "Result of marshalling is different as the one expected")
+
+	r, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddMapKey("map", testMap{"b": 2})
+		enc.AddMapKey("nil", nil)
+		enc.AddInterfaceKey("iface", testMap{"c": 3})
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddMap(testMap{"d": 4})
+			enc.AddMap(nil)
+			enc.AddInterface(testMap{})
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"map":{"b":2},"iface":{"c":3},"arr":[{"d":4},{}]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+
+	r, err = MarshalAppend([]byte(`x`), testMap{"e": 5})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `x{"e":5}`, string(r), "Result of marshalling is different as the one expected")
+}
+
+func TestEncoderMapSortKeys(t *testing.T) {
+	m := testMapObjects{
+		"b": {testStr: "b", testInt: 2},
+		"é": {testStr: "e"},
+		"B": {testStr: "B"},
+		"a": {testStr: "a", testInt: 1},
+	}
+	for i := 0; i < 20; i++ {
+		enc := NewEncoder()
+		enc.SetSortMapKeys(true)
+		err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntKey("z", 1)
+			enc.AddMapKey("m", m)
+			enc.AddIntKey("y", 2)
+		}))
+		assert.Nil(t, err, "Error should be nil")
+		// only the map is sorted, not the objects around and in it
+		single, _ := MarshalObject(m["B"])
+		a, _ := MarshalObject(m["a"])
+		b, _ := MarshalObject(m["b"])
+		e, _ := MarshalObject(m["é"])
+		assert.Equal(
+			t,
+			`{"z":1,"m":{"B":`+string(single)+`,"a":`+string(a)+`,"b":`+string(b)+`,"é":`+string(e)+`},"y":2}`,
+			string(enc.Bytes()),
+			"map keys should be sorted")
+		assert.Equal(t, 0, len(enc.sortFrames), "sort frames should be popped")
+		enc.addToPool()
+	}
+}
@@ -0,0 +1,65 @@
+package gojay
+
+import (
+	"math"
+	"strconv"
+)
+
+// NaNPolicy is how the Encoder handles the floats JSON cannot represent: NaN, +Inf and -Inf.
+type NaNPolicy int
+
+// Policies for NaN and infinite floats.
+const (
+	// NaNError returns an InvalidTypeError without writing anything, as encoding/json does
+	NaNError NaNPolicy = iota
+	// NaNNull writes null in place of the float
+	NaNNull
+	// NaNString writes the float as one of the strings "NaN", "Infinity" or "-Infinity"
+	NaNString
+)
+
+// SetNaNPolicy sets how NaN and infinite floats are encoded, NaNError if not set.
+func (enc *Encoder) SetNaNPolicy(policy NaNPolicy) {
+	enc.nanPolicy = policy
+}
+
+// checkFloat returns an error if n cannot be encoded with the policy of the Encoder, the error is also set with SetError.
+// It is called before anything is written for n.
+func (enc *Encoder) checkFloat(n float64) error {
+	if enc.nanPolicy != NaNError || !isNonFinite(n) {
+		return nil
+	}
+	err := InvalidTypeError("Cannot marshal float " + strconv.FormatFloat(n, 'g', -1, 64))
+	enc.SetError(err)
+	return err
+}
+
+// writeNonFinite writes n according to the policy of the Encoder if it is NaN or infinite,
+// it returns the Kind of the value written, 0 if n is finite and nothing was written.
+func (enc *Encoder) writeNonFinite(n float64) Kind {
+	if !isNonFinite(n) {
+		return 0
+	}
+	if enc.nanPolicy == NaNString {
+		switch {
+		case math.IsNaN(n):
+			enc.writeString(`"NaN"`)
+		case n > 0:
+			enc.writeString(`"Infinity"`)
+		default:
+			enc.writeString(`"-Infinity"`)
+		}
+		return KindString
+	}
+	enc.writeString("null")
+	return KindNull
+}
+
+// floatNull reports whether n is written as null with the policy of the Encoder.
+func (enc *Encoder) floatNull(n float64) bool {
+	return enc.nanPolicy == NaNNull && isNonFinite(n)
+}
+
+func isNonFinite(n float64) bool {
+	return math.IsNaN(n) || math.IsInf(n, 0)
+}
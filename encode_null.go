@@ -0,0 +1,43 @@
+package gojay
+
+// SetStripNulls sets whether null values must be omitted from the output.
+// When set, AddNullKey and every method writing a null value for a key omit the key entirely,
+// and AddNull and every method writing a null array element omit the element.
+func (enc *Encoder) SetStripNulls(strip bool) {
+	enc.stripNulls = strip
+}
+
+// AddNull adds a null to be encoded, must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddNull() error {
+	if enc.stripNulls {
+		return nil
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeString("null")
+	enc.record(KindNull, start)
+	return nil
+}
+
+// AddNullKey adds a null to be encoded, must be used inside an object as it will encode a key
+// The key is written with an explicit null, so that a field set to null can be told apart from an absent one,
+// for instance to remove a member in a JSON Merge Patch (RFC 7386). SetStripNulls must not be set in that case.
+func (enc *Encoder) AddNullKey(key string) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddNullKey(key) })
+	}
+	if enc.stripNulls {
+		return nil
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	enc.writeString("null")
+	enc.record(KindNull, start)
+	return nil
+}
@@ -0,0 +1,180 @@
+package gojay
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderNull(t *testing.T) {
+	var nilStringer *testStringerPtr
+	obj := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddNullKey("a")
+		enc.AddStringerKey("b", nilStringer)
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddNull()
+			enc.AddInt(1)
+			enc.AddNull()
+		}))
+		enc.AddNullKey("c")
+	})
+	testCases := []struct {
+		name     string
+		strip    bool
+		obj      MarshalerObject
+		expected string
+	}{
+		{
+			name:     "nulls",
+			obj:      obj,
+			expected: `{"a":null,"b":null,"arr":[null,1,null],"c":null}`,
+		},
+		{
+			name:     "strip-nulls",
+			strip:    true,
+			obj:      obj,
+			expected: `{"arr":[1]}`,
+		},
+		{
+			name:  "strip-nulls-every-field-null",
+			strip: true,
+			obj: EncodeObjectFunc(func(enc *Encoder) {
+				enc.AddNullKey("a")
+				enc.AddStringerKey("b", nil)
+				enc.AddNullKey("c")
+			}),
+			expected: `{}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enc := NewEncoder()
+			defer enc.addToPool()
+			enc.SetStripNulls(testCase.strip)
+			err := enc.AddObject(testCase.obj)
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+		})
+	}
+}
+
+func TestEncoderStripNullsPaginate(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetStripNulls(true)
+	err := enc.Paginate(func(enc *Encoder) {}, "", false)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"items":[],"has_more":false}`, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+}
+
+func TestEncoderStripNullsEveryPath(t *testing.T) {
+	var nilPtr *int
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetStripNulls(true)
+	enc.SetNaNPolicy(NaNNull)
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddBytesKey("b", nil)
+		enc.AddFloatKey("f", math.NaN())
+		enc.AddFloat32Key("f32", float32(math.Inf(1)))
+		enc.AddFloatKeyWithPrecision("fp", math.NaN(), 2)
+		enc.AddFloatPreparedKey(PrecomputeKey("fk"), math.NaN())
+		enc.AddInterfaceKey("p", nilPtr)
+		enc.AddInterfaceKey("s", struct {
+			P *int
+			M map[string]int
+			V []float64
+		}{V: []float64{1, math.NaN()}})
+		enc.AddFloatArrayKeyQuantized("q", []float64{math.NaN(), 1.5, math.Inf(-1)}, 3)
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddBytes(nil)
+			enc.AddFloat(math.NaN())
+			enc.AddFloatWithPrecision(math.Inf(1), 2)
+			enc.AddInterface(nilPtr)
+			enc.AddInt(1)
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"s":{"V":[1]},"q":[1.5],"arr":[1]}`, string(enc.Bytes()), "every null should be omitted")
+}
+
+type testMergePatch struct {
+	name    *string
+	nick    *string
+	deleted []string
+}
+
+func (p *testMergePatch) IsNil() bool {
+	return p == nil
+}
+
+func (p *testMergePatch) MarshalObject(enc *Encoder) {
+	if p.name != nil {
+		enc.AddStringKey("name", *p.name)
+	}
+	if p.nick != nil {
+		enc.AddStringKey("nick", *p.nick)
+	}
+	for _, key := range p.deleted {
+		enc.AddNullKey(key)
+	}
+}
+
+func TestEncoderNullMergePatch(t *testing.T) {
+	name := "John"
+	r, err := MarshalObject(&testMergePatch{
+		name:    &name,
+		deleted: []string{"nick", "address"},
+	})
+	assert.Nil(t, err, "Error should be nil")
+	// nick is removed while absent fields are left unchanged
+	assert.Equal(t, `{"name":"John","nick":null,"address":null}`, string(r), "Result of marshalling is different as the one expected")
+}
+
+type testNilArray []int
+
+func (a testNilArray) MarshalArray(enc *Encoder) {
+	for _, v := range a {
+		enc.AddInt(v)
+	}
+}
+
+func (a testNilArray) IsNil() bool {
+	return a == nil
+}
+
+func TestEncoderNilMarshalers(t *testing.T) {
+	var nilObj *testObject
+	v := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddObjectKey("obj", nilObj)
+		enc.AddArrayKey("arr", testNilArray(nil))
+		enc.AddArrayKey("empty", testNilArray{})
+		enc.AddArrayKey("iface", nil)
+		enc.AddArrayKey("list", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddObject(nilObj)
+			enc.AddArray(testNilArray(nil))
+		}))
+	})
+	r, err := MarshalObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"obj":null,"arr":null,"empty":[],"iface":null,"list":[null,null]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetStripNulls(true)
+	err = enc.AddObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"empty":[],"list":[]}`, string(enc.Bytes()), "nil values should be skipped")
+
+	r, err = MarshalObject(nilObj)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `null`, string(r), "nil object should be encoded as null")
+	r, err = Marshal(testNilArray(nil))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `null`, string(r), "nil array should be encoded as null")
+}
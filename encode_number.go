@@ -9,73 +9,334 @@ func (enc *Encoder) encodeInt(n int64) ([]byte, error) {
 	return enc.buf, nil
 }
 
+// encodeUint encodes an unsigned int to JSON, preserving the full range of uint64
+func (enc *Encoder) encodeUint(n uint64) ([]byte, error) {
+	enc.buf = strconv.AppendUint(enc.buf, n, 10)
+	return enc.buf, nil
+}
+
 // encodeFloat encodes a float64 to JSON
+//
+// Floats are formatted by strconv as the shortest decimal representation parsing back to the same float64,
+// in pure Go, so a given value is encoded to the same bytes on every platform.
 func (enc *Encoder) encodeFloat(n float64) ([]byte, error) {
-	s := strconv.FormatFloat(n, 'f', -1, 64)
-	enc.writeString(s)
+	if err := enc.checkFloat(n); err != nil {
+		return nil, err
+	}
+	enc.writeFloat(n, 64)
 	return enc.buf, nil
 }
 
+// SetFloatFormat sets the format floats are written in, as strconv.FormatFloat's fmt:
+// 'f' for -ddd.dddd, 'e' for -d.dddde±dd, or 'g' for 'e' with large exponents and 'f' otherwise.
+// The default is 'f', other formats are treated as 'f'.
+func (enc *Encoder) SetFloatFormat(format byte) {
+	switch format {
+	case 'f', 'e', 'g':
+		enc.floatFormat = format
+	default:
+		enc.floatFormat = 'f'
+	}
+}
+
+// SetFloatPrecision sets the number of digits floats are written with, as strconv.FormatFloat's prec:
+// the number of digits after the decimal point for the 'f' and 'e' formats, the number of significant digits for 'g'.
+// A negative precision, the default, writes the shortest representation parsing back to the same float.
+func (enc *Encoder) SetFloatPrecision(precision int) {
+	enc.floatPrecision = precision
+	enc.hasFloatPrecision = precision >= 0
+}
+
+// writeFloat writes n, a float of bitSize bits, in the format and with the precision set on the Encoder,
+// by default as the shortest decimal representation parsing back to n.
+// In canonical mode, it is formatted as ECMAScript does, see MarshalCanonical.
+// It returns the Kind of the value written, which is not a number for NaN and infinite floats, see SetNaNPolicy.
+func (enc *Encoder) writeFloat(n float64, bitSize int) Kind {
+	precision := -1
+	if enc.hasFloatPrecision {
+		precision = enc.floatPrecision
+	}
+	return enc.writeFloatPrecision(n, bitSize, precision)
+}
+
+// writeFloatPrecision writes n, a float of bitSize bits, with precision digits in the format set on the Encoder.
+func (enc *Encoder) writeFloatPrecision(n float64, bitSize int, precision int) Kind {
+	if k := enc.writeNonFinite(n); k != 0 {
+		return k
+	}
+	if enc.canonical {
+		enc.buf = appendCanonicalFloat(enc.buf, n)
+		return KindNumber
+	}
+	format := enc.floatFormat
+	if format == 0 {
+		format = 'f'
+	}
+	enc.buf = strconv.AppendFloat(enc.buf, n, format, precision, bitSize)
+	return KindNumber
+}
+
 // AddInt adds an int to be encoded, must be used inside a slice or array encoding (does not encode a key)
 func (enc *Encoder) AddInt(value int) error {
-	r, ok := enc.getPreviousRune()
-	if ok && r != '[' {
-		enc.writeByte(',')
-	}
+	start := enc.offset()
+	enc.writeSep()
 	enc.buf = strconv.AppendInt(enc.buf, int64(value), 10)
+	enc.record(KindNumber, start)
+	return nil
+}
+
+// AddUint64 adds an uint64 to be encoded, must be used inside a slice or array encoding (does not encode a key)
+// Values above math.MaxInt64 are written as is, unlike with AddInt.
+func (enc *Encoder) AddUint64(value uint64) error {
+	start := enc.offset()
+	enc.writeSep()
+	enc.buf = strconv.AppendUint(enc.buf, value, 10)
+	enc.record(KindNumber, start)
 	return nil
 }
 
 // AddFloat adds a float64 to be encoded, must be used inside a slice or array encoding (does not encode a key)
+// By default the output is the shortest decimal representation of value, see SetFloatFormat and SetFloatPrecision.
+// It does not depend on the platform.
 func (enc *Encoder) AddFloat(value float64) error {
-	r, ok := enc.getPreviousRune()
-	if ok && r != '[' {
-		enc.writeByte(',')
+	if err := enc.checkFloat(value); err != nil {
+		return err
 	}
-	enc.buf = strconv.AppendFloat(enc.buf, value, 'f', -1, 64)
+	if enc.floatNull(value) {
+		return enc.AddNull()
+	}
+	start := enc.offset()
+	enc.writeSep()
+	k := enc.writeFloat(value, 64)
+	enc.record(k, start)
+	return nil
+}
 
+// AddFloatWithPrecision adds a float64 to be encoded with precision digits, must be used inside a slice or array encoding (does not encode a key)
+// precision is the number of digits after the decimal point, or of significant digits with the 'g' format,
+// it overrides the one set with SetFloatPrecision. A negative precision writes the shortest representation.
+func (enc *Encoder) AddFloatWithPrecision(value float64, precision int) error {
+	if err := enc.checkFloat(value); err != nil {
+		return err
+	}
+	if enc.floatNull(value) {
+		return enc.AddNull()
+	}
+	start := enc.offset()
+	enc.writeSep()
+	k := enc.writeFloatPrecision(value, 64, precision)
+	enc.record(k, start)
 	return nil
 }
 
 // AddIntKey adds an int to be encoded, must be used inside an object as it will encode a key
 func (enc *Encoder) AddIntKey(key string, value int) error {
-	r, ok := enc.getPreviousRune()
-	if ok && r != '{' && r != '[' {
-		enc.writeByte(',')
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddIntKey(key, value) })
 	}
+	start := enc.offset()
+	enc.writeSep()
 	enc.writeByte('"')
-	enc.writeString(key)
-	enc.write(objKey)
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
 	enc.buf = strconv.AppendInt(enc.buf, int64(value), 10)
 
+	enc.record(KindNumber, start)
 	return nil
 }
 
+// AddUint64Key adds an uint64 to be encoded, must be used inside an object as it will encode a key
+// Values above math.MaxInt64 are written as is, unlike with AddIntKey.
+func (enc *Encoder) AddUint64Key(key string, value uint64) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddUint64Key(key, value) })
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	enc.buf = strconv.AppendUint(enc.buf, value, 10)
+	enc.record(KindNumber, start)
+	return nil
+}
+
+// AddIntKeyOmitEmpty adds an int to be encoded, must be used inside an object as it will encode a key
+// If value is 0, nothing is written, not even the key.
+func (enc *Encoder) AddIntKeyOmitEmpty(key string, value int) error {
+	if value == 0 {
+		return nil
+	}
+	return enc.AddIntKey(key, value)
+}
+
 // AddFloatKey adds a float64 to be encoded, must be used inside an object as it will encode a key
 func (enc *Encoder) AddFloatKey(key string, value float64) error {
-	r, ok := enc.getPreviousRune()
-	if ok && r != '{' && r != '[' {
-		enc.writeByte(',')
+	if enc.skipKey(key) {
+		return nil
 	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddFloatKey(key, value) })
+	}
+	if err := enc.checkFloat(value); err != nil {
+		return err
+	}
+	if enc.floatNull(value) {
+		return enc.AddNullKey(key)
+	}
+	start := enc.offset()
+	enc.writeSep()
 	enc.writeByte('"')
-	enc.writeString(key)
-	enc.write(objKey)
-	enc.buf = strconv.AppendFloat(enc.buf, value, 'f', -1, 64)
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	k := enc.writeFloat(value, 64)
+	enc.record(k, start)
+	return nil
+}
 
+// AddFloatKeyOmitEmpty adds a float64 to be encoded, must be used inside an object as it will encode a key
+// If value is 0, nothing is written, not even the key.
+func (enc *Encoder) AddFloatKeyOmitEmpty(key string, value float64) error {
+	if value == 0 {
+		return nil
+	}
+	return enc.AddFloatKey(key, value)
+}
+
+// AddFloatKeyWithPrecision adds a float64 to be encoded with precision digits, must be used inside an object as it will encode a key
+// precision is the number of digits after the decimal point, or of significant digits with the 'g' format,
+// it overrides the one set with SetFloatPrecision. A negative precision writes the shortest representation.
+// For example AddFloatKeyWithPrecision("price", 9.5, 2) encodes "price":9.50.
+func (enc *Encoder) AddFloatKeyWithPrecision(key string, value float64, precision int) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddFloatKeyWithPrecision(key, value, precision) })
+	}
+	if err := enc.checkFloat(value); err != nil {
+		return err
+	}
+	if enc.floatNull(value) {
+		return enc.AddNullKey(key)
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	k := enc.writeFloatPrecision(value, 64, precision)
+	enc.record(k, start)
 	return nil
 }
 
 // AddFloat32Key adds a float32 to be encoded, must be used inside an object as it will encode a key
 func (enc *Encoder) AddFloat32Key(key string, value float32) error {
-	r, ok := enc.getPreviousRune()
-	if ok && r != '{' && r != '[' {
-		enc.writeByte(',')
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddFloat32Key(key, value) })
+	}
+	if err := enc.checkFloat(float64(value)); err != nil {
+		return err
 	}
+	if enc.floatNull(float64(value)) {
+		return enc.AddNullKey(key)
+	}
+	start := enc.offset()
+	enc.writeSep()
 	enc.writeByte('"')
-	enc.writeString(key)
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	k := enc.writeFloat(float64(value), 32)
+	enc.record(k, start)
+	return nil
+}
+
+// AddFloatWithUnit adds a float64 followed by unit as a JSON string, must be used inside a slice or array encoding (does not encode a key)
+// For example AddFloatWithUnit(12.5, "ms") encodes "12.5ms".
+func (enc *Encoder) AddFloatWithUnit(value float64, unit string) error {
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeFloatWithUnit(value, unit)
+	enc.record(KindString, start)
+	return nil
+}
+
+// AddFloatWithUnitKey adds a float64 followed by unit as a JSON string, must be used inside an object as it will encode a key
+// For example AddFloatWithUnitKey("latency", 12.5, "ms") encodes "latency":"12.5ms".
+func (enc *Encoder) AddFloatWithUnitKey(key string, value float64, unit string) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddFloatWithUnitKey(key, value, unit) })
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	enc.writeFloatWithUnit(value, unit)
+	enc.record(KindString, start)
+	return nil
+}
+
+// writeFloatWithUnit writes value with the float format and precision of the Encoder, followed by unit, as a JSON string.
+// NaN and infinite values are written as strconv formats them, the NaN policy does not apply inside a string.
+func (enc *Encoder) writeFloatWithUnit(value float64, unit string) {
 	enc.writeByte('"')
-	enc.writeByte(':')
-	enc.buf = strconv.AppendFloat(enc.buf, float64(value), 'f', -1, 32)
+	if isNonFinite(value) {
+		enc.buf = strconv.AppendFloat(enc.buf, value, 'f', -1, 64)
+	} else {
+		enc.writeFloat(value, 64)
+	}
+	enc.writeStringEscape(unit)
+	enc.writeByte('"')
+}
 
+// AddFloatArrayKeyQuantized adds an array of floats to be encoded, must be used inside an object as it will encode a key
+// Each value is written rounded to sigDigits significant digits, using strconv's 'g' format,
+// large and small values are therefore written with an exponent, such as 1.23e+06.
+// If sigDigits is lower than 1, values are written at full precision.
+func (enc *Encoder) AddFloatArrayKeyQuantized(key string, values []float64, sigDigits int) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddFloatArrayKeyQuantized(key, values, sigDigits) })
+	}
+	if sigDigits < 1 {
+		sigDigits = -1
+	}
+	for _, v := range values {
+		if err := enc.checkFloat(v); err != nil {
+			return err
+		}
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKeyArr)
+	enc.enter()
+	for _, v := range values {
+		if enc.stripNulls && enc.floatNull(v) {
+			continue
+		}
+		enc.writeSep()
+		if enc.writeNonFinite(v) == 0 {
+			enc.buf = strconv.AppendFloat(enc.buf, v, 'g', sigDigits, 64)
+		}
+	}
+	enc.writeClose(']')
+	enc.record(KindArray, start)
 	return nil
 }
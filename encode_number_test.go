@@ -1,6 +1,11 @@
 package gojay
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -101,3 +106,299 @@ func TestEncoderFloat(t *testing.T) {
 		string(r),
 		"Result of marshalling is different as the one expected")
 }
+
+func TestEncoderFloatDeterministic(t *testing.T) {
+	testCases := []struct {
+		value    float64
+		expected string
+	}{
+		{0.1, `0.1`},
+		{0.30000000000000004, `0.30000000000000004`},
+		{1.0 / 3, `0.3333333333333333`},
+		{123456789.123456789, `123456789.12345679`},
+		{1e21, `1000000000000000000000`},
+		{2.5e-8, `0.000000025`},
+		{math.Copysign(0, -1), `-0`},
+		{9007199254740993, `9007199254740992`},
+	}
+	for _, testCase := range testCases {
+		r, err := Marshal(testCase.value)
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, testCase.expected, string(r), "Result of marshalling is different as the one expected")
+	}
+	// seeded random bit patterns, the checksum of the output is the same on every platform
+	rnd := rand.New(rand.NewSource(42))
+	floats := make([]float64, 0, 10000)
+	for len(floats) < cap(floats) {
+		f := math.Float64frombits(rnd.Uint64())
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			continue
+		}
+		floats = append(floats, f)
+	}
+	r, err := MarshalArray(EncodeArrayFunc(func(enc *Encoder) {
+		for _, f := range floats {
+			enc.AddFloat(f)
+		}
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		"5f56fab90ef780f8ce5b02eaf6ef945473b6b5a2a10368b5fd9d87ce0bd98c26",
+		fmt.Sprintf("%x", sha256.Sum256(r)),
+		"checksum of the encoded floats should be the expected one")
+	dec := newDecoder(nil, 0)
+	defer dec.addToPool()
+	dec.data = r
+	dec.length = len(r)
+	i := 0
+	err = dec.rawArray(func(start, end int) error {
+		f, err := strconv.ParseFloat(string(r[start:end]), 64)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, math.Float64bits(floats[i]), math.Float64bits(f), "encoded float should parse back to the same value")
+		i++
+		return nil
+	})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, len(floats), i, "all floats should be read back")
+}
+
+type testFloatWithUnit struct {
+	latency float64
+	size    float64
+}
+
+func (t *testFloatWithUnit) IsNil() bool {
+	return t == nil
+}
+
+func (t *testFloatWithUnit) MarshalObject(enc *Encoder) {
+	enc.AddFloatWithUnitKey("latency", t.latency, "ms")
+	enc.AddFloatWithUnitKey("size", t.size, "GB")
+}
+
+type testFloatWithUnitSlice []float64
+
+func (t testFloatWithUnitSlice) MarshalArray(enc *Encoder) {
+	for _, f := range t {
+		enc.AddFloatWithUnit(f, "s")
+	}
+}
+
+func TestEncoderFloatWithUnit(t *testing.T) {
+	r, err := MarshalObject(&testFloatWithUnit{12.5, 3.2})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"latency":"12.5ms","size":"3.2GB"}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+	r, err = MarshalArray(testFloatWithUnitSlice{1, 1.5})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`["1s","1.5s"]`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetFloatPrecision(2)
+	err = enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddFloatWithUnitKey("latency", 12.3456, "ms")
+		enc.AddFloatKey("plain", 12.3456)
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddFloatWithUnit(1.005, "s")
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"latency":"12.35ms","plain":12.35,"arr":["1.00s"]}`,
+		string(enc.Bytes()),
+		"float settings should apply to the number before the unit")
+}
+
+func TestEncoderFloatArrayKeyQuantized(t *testing.T) {
+	values := []float64{3.14159265, 2.71828182, -0.000123456, 1234567.89, 42, 0}
+	r, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddFloatArrayKeyQuantized("values", values, 3)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"values":[3.14,2.72,-0.000123,1.23e+06,42,0]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+
+	full, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddFloatArrayKeyQuantized("values", values, 0)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"values":[3.14159265,2.71828182,-0.000123456,1.23456789e+06,42,0]}`,
+		string(full),
+		"Result of marshalling is different as the one expected")
+	assert.True(t, len(r) < len(full), "quantized output should be smaller than full precision output")
+}
+
+func TestEncoderFloatPrecision(t *testing.T) {
+	testCases := []struct {
+		name      string
+		format    byte
+		precision int
+		expected  string
+	}{
+		{
+			name:      "default",
+			precision: -1,
+			expected:  `{"a":9.5,"b":0.000125,"c":1000000000000000000000,"price":9.50,"arr":[1.2345,3]}`,
+		},
+		{
+			name:      "fixed",
+			format:    'f',
+			precision: 3,
+			expected:  `{"a":9.500,"b":0.000,"c":1000000000000000000000.000,"price":9.50,"arr":[1.2345,3]}`,
+		},
+		{
+			name:      "exponent",
+			format:    'e',
+			precision: 2,
+			expected:  `{"a":9.50e+00,"b":1.25e-04,"c":1.00e+21,"price":9.50e+00,"arr":[1.2345e+00,3e+00]}`,
+		},
+		{
+			name:      "general",
+			format:    'g',
+			precision: -1,
+			expected:  `{"a":9.5,"b":0.000125,"c":1e+21,"price":9.5,"arr":[1.2345,3]}`,
+		},
+		{
+			name:      "unknown-format",
+			format:    'x',
+			precision: 1,
+			expected:  `{"a":9.5,"b":0.0,"c":1000000000000000000000.0,"price":9.50,"arr":[1.2345,3]}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enc := NewEncoder()
+			defer enc.addToPool()
+			if testCase.format != 0 {
+				enc.SetFloatFormat(testCase.format)
+			}
+			enc.SetFloatPrecision(testCase.precision)
+			err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+				enc.AddFloatKey("a", 9.5)
+				enc.AddFloatKey("b", 0.000125)
+				enc.AddFloatKey("c", 1e21)
+				enc.AddFloatKeyWithPrecision("price", 9.5, 2)
+				enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+					enc.AddFloatWithPrecision(1.2345, -1)
+					enc.AddFloatWithPrecision(3, 0)
+				}))
+			}))
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+		})
+	}
+}
+
+func TestEncoderNaNPolicy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   NaNPolicy
+		expected string
+	}{
+		{
+			name:     "null",
+			policy:   NaNNull,
+			expected: `{"nan":null,"inf":null,"ninf":null,"f32":null,"arr":[null,1.5],"q":[null,1.23]}`,
+		},
+		{
+			name:     "string",
+			policy:   NaNString,
+			expected: `{"nan":"NaN","inf":"Infinity","ninf":"-Infinity","f32":"Infinity","arr":["NaN",1.5],"q":["-Infinity",1.23]}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enc := NewEncoder()
+			defer enc.addToPool()
+			enc.SetNaNPolicy(testCase.policy)
+			err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+				enc.AddFloatKey("nan", math.NaN())
+				enc.AddFloatKey("inf", math.Inf(1))
+				enc.AddFloatKeyWithPrecision("ninf", math.Inf(-1), 2)
+				enc.AddFloat32Key("f32", float32(math.Inf(1)))
+				enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+					enc.AddFloat(math.NaN())
+					enc.AddFloat(1.5)
+				}))
+				enc.AddFloatArrayKeyQuantized("q", []float64{math.Inf(-1), 1.234}, 3)
+			}))
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+		})
+	}
+}
+
+func TestEncoderNaNPolicyError(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	err := enc.AddFloat(math.NaN())
+	assert.NotNil(t, err, "Error should not be nil")
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	err = enc.AddFloatKey("inf", math.Inf(1))
+	assert.NotNil(t, err, "Error should not be nil")
+	err = enc.AddFloatArrayKeyQuantized("q", []float64{1, math.Inf(-1)}, 3)
+	assert.NotNil(t, err, "Error should not be nil")
+	assert.Equal(t, "", string(enc.Bytes()), "nothing should be written")
+	assert.IsType(t, InvalidTypeError(""), enc.Err(), "error should be set on the encoder")
+	_, err = Marshal(math.NaN())
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	r, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddFloatKey("nan", math.NaN())
+		enc.AddIntKey("ok", 1)
+	}))
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	assert.Nil(t, r, "nothing should be returned")
+}
+
+func TestEncoderUint64FullRange(t *testing.T) {
+	testCases := []struct {
+		value    interface{}
+		expected string
+	}{
+		{uint64(math.MaxUint64), `18446744073709551615`},
+		{uint64(math.MaxInt64 + 1), `9223372036854775808`},
+		{uint(math.MaxUint32), `4294967295`},
+		{uintptr(42), `42`},
+	}
+	for _, testCase := range testCases {
+		r, err := Marshal(testCase.value)
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, testCase.expected, string(r), "Result of marshalling is different as the one expected")
+		r, err = MarshalAppend(nil, testCase.value)
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, testCase.expected, string(r), "Result of marshalling is different as the one expected")
+	}
+	enc := NewEncoder()
+	defer enc.addToPool()
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddUint64Key("max", math.MaxUint64)
+		enc.AddInterfaceKey("iface", uint64(math.MaxUint64))
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddUint64(math.MaxUint64)
+			enc.AddInterface(uint(7))
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"max":18446744073709551615,"iface":18446744073709551615,"arr":[18446744073709551615,7]}`,
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+}
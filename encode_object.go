@@ -6,35 +6,148 @@ var objKeyArr = []byte(`":[`)
 var objKey = []byte(`":`)
 
 // AddObject adds an object to be encoded, must be used inside a slice or array encoding (does not encode a key)
-// value must implement Marshaler
+// value must implement Marshaler, if value is nil, null is added as with AddNull.
 func (enc *Encoder) AddObject(value MarshalerObject) error {
-	if value.IsNil() {
-		return nil
+	if value == nil || value.IsNil() {
+		return enc.AddNull()
 	}
-	r, ok := enc.getPreviousRune()
-	if ok && r != '[' {
-		enc.writeByte(',')
+	if enc.err != nil {
+		return enc.err
 	}
-	enc.writeByte('{')
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeOpen('{')
 	value.MarshalObject(enc)
-	enc.writeByte('}')
-	return nil
+	enc.writeClose('}')
+	enc.record(KindObject, start)
+	return enc.err
 }
 
 // AddObjectKey adds a struct to be encoded, must be used inside an object as it will encode a key
-// value must implement Marshaler
+// value must implement Marshaler, if value is nil, the key is added with null as with AddNullKey,
+// so it is omitted if SetStripNulls is set. See AddObjectKeyOmitEmpty to always omit it.
 func (enc *Encoder) AddObjectKey(key string, value MarshalerObject) error {
-	if value.IsNil() {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddObjectKey(key, value) })
+	}
+	if value == nil || value.IsNil() {
+		return enc.AddNullKey(key)
+	}
+	if enc.err != nil {
+		return enc.err
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKeyObj)
+	enc.enter()
+	value.MarshalObject(enc)
+	enc.writeClose('}')
+	enc.record(KindObject, start)
+	return enc.err
+}
+
+// AddObjectKeyOmitEmpty adds a struct to be encoded, must be used inside an object as it will encode a key
+// If value is nil or adds no key, nothing is written, not even the key.
+func (enc *Encoder) AddObjectKeyOmitEmpty(key string, value MarshalerObject) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddObjectKeyOmitEmpty(key, value) })
+	}
+	if value == nil || value.IsNil() {
 		return nil
 	}
-	r, ok := enc.getPreviousRune()
-	if ok && r != '{' && r != '[' {
-		enc.writeByte(',')
+	if enc.err != nil {
+		return enc.err
 	}
+	start := enc.offset()
+	mark, hasValue := len(enc.buf), enc.hasValue
+	// the key is removed from the buffer if the object is empty, it must not be flushed meanwhile
+	enc.pinned++
+	enc.writeSep()
 	enc.writeByte('"')
-	enc.writeString(key)
-	enc.write(objKeyObj)
+	enc.writeKey(key)
+	enc.writeObjKey(objKeyObj)
+	enc.enter()
 	value.MarshalObject(enc)
-	enc.writeByte('}')
+	empty := !enc.hasValue
+	enc.writeClose('}')
+	enc.pinned--
+	if empty {
+		enc.truncate(mark, hasValue)
+		return enc.err
+	}
+	enc.record(KindObject, start)
+	return enc.err
+}
+
+// AddFlattened adds the keys of value to the object being encoded, without a key nor braces of its own,
+// as the fields of an embedded struct are promoted to the struct embedding it. Must be used inside an object.
+// Keys are not deduplicated, a key added by value and by the current object is written twice.
+// If value is nil, nothing is written.
+func (enc *Encoder) AddFlattened(value MarshalerObject) error {
+	if value == nil || value.IsNil() {
+		return nil
+	}
+	if enc.err != nil {
+		return enc.err
+	}
+	value.MarshalObject(enc)
+	return enc.err
+}
+
+// writeObject writes v as an object, or null if v is nil, and returns the Kind written.
+func (enc *Encoder) writeObject(v MarshalerObject) Kind {
+	if v == nil || v.IsNil() {
+		enc.writeString("null")
+		return KindNull
+	}
+	enc.writeOpen('{')
+	v.MarshalObject(enc)
+	enc.writeClose('}')
+	return KindObject
+}
+
+// truncate removes from the buffer everything written from mark, the separator state is restored to hasValue.
+func (enc *Encoder) truncate(mark int, hasValue bool) {
+	enc.buf = enc.buf[:mark]
+	enc.hasValue = hasValue
+	if enc.sorting() {
+		enc.dropFields()
+	}
+}
+
+// AddObjectKeyCompact adds an object to be encoded, must be used inside an object as it will encode a key
+// f is called to add the keys of the nested object.
+//
+// The nested object is always written without indentation, whatever the indentation settings of the Encoder,
+// the settings apply again once the nested object is closed.
+func (enc *Encoder) AddObjectKeyCompact(key string, f func(enc *Encoder)) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddObjectKeyCompact(key, f) })
+	}
+	if f == nil {
+		return nil
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	enc.compact++
+	enc.writeOpen('{')
+	f(enc)
+	enc.writeClose('}')
+	enc.compact--
+	enc.record(KindObject, start)
 	return nil
 }
@@ -131,7 +131,7 @@ func TestEncoderComplexObject(t *testing.T) {
 	assert.Nil(t, err, "Error should be nil")
 	assert.Equal(
 		t,
-		`{"test":"hello world","test2":"foobar","testInt":1,"testBool":true,"testArr":[{"test":"1","test2":"","testInt":0,"testBool":false,"testArr":[],"testF64":0,"testF32":0}],"testF64":120.15,"testF32":120.53,"testInterface":true,"sub":{"test1":10,"test2":"hello world","test3":1.23543,"testBool":true,"sub":{"test1":10,"test2":"hello world","test3":0,"testBool":false}}}`,
+		`{"test":"hello world","test2":"foobar","testInt":1,"testBool":true,"testArr":[{"test":"1","test2":"","testInt":0,"testBool":false,"testArr":[],"testF64":0,"testF32":0,"sub":null}],"testF64":120.15,"testF32":120.53,"testInterface":true,"sub":{"test1":10,"test2":"hello world","test3":1.23543,"testBool":true,"sub":{"test1":10,"test2":"hello world","test3":0,"testBool":false,"sub":null}}}`,
 		string(r),
 		"Result of marshalling is different as the one expected",
 	)
@@ -247,3 +247,123 @@ func TestObjInterfaces(t *testing.T) {
 		string(r),
 		"Result of marshalling is different as the one expected")
 }
+
+func TestEncoderObjectKeyCompact(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("header", "readable")
+		enc.AddObjectKeyCompact("data", func(enc *Encoder) {
+			assert.Equal(t, 1, enc.compact, "encoder should be in compact mode")
+			enc.AddIntKey("a", 1)
+			enc.AddObjectKeyCompact("b", func(enc *Encoder) {
+				enc.AddIntKey("c", 2)
+			})
+			assert.Equal(t, 1, enc.compact, "encoder should still be in compact mode")
+		})
+		assert.Equal(t, 0, enc.compact, "encoder should not be in compact mode anymore")
+		enc.AddStringKey("footer", "readable")
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"header":"readable","data":{"a":1,"b":{"c":2}},"footer":"readable"}`,
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderOmitEmpty(t *testing.T) {
+	var nilObj *testObject
+	fields := func(enc *Encoder) {
+		enc.AddStringKeyOmitEmpty("emptyStr", "")
+		enc.AddIntKeyOmitEmpty("zeroInt", 0)
+		enc.AddFloatKeyOmitEmpty("zeroFloat", 0)
+		enc.AddBoolKeyOmitEmpty("false", false)
+		enc.AddObjectKeyOmitEmpty("nilObj", nilObj)
+		enc.AddObjectKeyOmitEmpty("nilIface", nil)
+		enc.AddObjectKeyOmitEmpty("emptyObj", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddStringKeyOmitEmpty("emptyStr", "")
+		}))
+		enc.AddArrayKeyOmitEmpty("nilArr", nil)
+		enc.AddArrayKeyOmitEmpty("nilSlice", testEncodingArrInterfaces(nil))
+		enc.AddArrayKeyOmitEmpty("emptyArr", EncodeArrayFunc(func(enc *Encoder) {}))
+		enc.AddStringKeyOmitEmpty("str", "s")
+		enc.AddIntKeyOmitEmpty("int", 1)
+		enc.AddFloatKeyOmitEmpty("float", 1.5)
+		enc.AddBoolKeyOmitEmpty("true", true)
+		enc.AddObjectKeyOmitEmpty("obj", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntKeyOmitEmpty("a", 1)
+		}))
+		enc.AddArrayKeyOmitEmpty("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddInt(0)
+		}))
+		enc.AddArrayKeyOmitEmpty("last", EncodeArrayFunc(func(enc *Encoder) {}))
+	}
+	r, err := MarshalObject(EncodeObjectFunc(fields))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"str":"s","int":1,"float":1.5,"true":true,"obj":{"a":1},"arr":[0]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+
+	r, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddObjectKeyOmitEmpty("a", EncodeObjectFunc(func(enc *Encoder) {}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{}`, string(r), "Result of marshalling is different as the one expected")
+
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetSortKeys(true)
+	enc.SetIndent("", " ")
+	err = enc.AddObject(EncodeObjectFunc(fields))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		"{\n \"arr\": [\n  0\n ],\n \"float\": 1.5,\n \"int\": 1,\n \"obj\": {\n  \"a\": 1\n },\n \"str\": \"s\",\n \"true\": true\n}",
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+}
+
+type testFlattenedAudit struct {
+	createdBy string
+	version   int
+}
+
+func (a *testFlattenedAudit) MarshalObject(enc *Encoder) {
+	enc.AddStringKey("createdBy", a.createdBy)
+	enc.AddIntKey("version", a.version)
+}
+
+func (a *testFlattenedAudit) IsNil() bool {
+	return a == nil
+}
+
+func TestEncoderFlattened(t *testing.T) {
+	audit := &testFlattenedAudit{"john", 2}
+	r, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddFlattened(audit)
+		enc.AddStringKey("id", "a")
+		enc.AddFlattened((*testFlattenedAudit)(nil))
+		enc.AddObjectKey("sub", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddFlattened(audit)
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"createdBy":"john","version":2,"id":"a","sub":{"createdBy":"john","version":2}}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetSortKeys(true)
+	err = enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("id", "a")
+		enc.AddFlattened(audit)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"createdBy":"john","id":"a","version":2}`, string(enc.Bytes()), "flattened keys should be sorted with the others")
+}
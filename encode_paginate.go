@@ -0,0 +1,66 @@
+package gojay
+
+// PaginationKeys holds the keys of the envelope written by Encoder.Paginate.
+// Empty fields fall back to the ones of DefaultPaginationKeys.
+type PaginationKeys struct {
+	Items      string
+	NextCursor string
+	HasMore    string
+}
+
+// DefaultPaginationKeys are the keys used by Encoder.Paginate unless SetPaginationKeys is called.
+var DefaultPaginationKeys = PaginationKeys{
+	Items:      "items",
+	NextCursor: "next_cursor",
+	HasMore:    "has_more",
+}
+
+// SetPaginationKeys sets the keys of the envelope written by Paginate.
+func (enc *Encoder) SetPaginationKeys(keys PaginationKeys) {
+	if keys.Items == "" {
+		keys.Items = DefaultPaginationKeys.Items
+	}
+	if keys.NextCursor == "" {
+		keys.NextCursor = DefaultPaginationKeys.NextCursor
+	}
+	if keys.HasMore == "" {
+		keys.HasMore = DefaultPaginationKeys.HasMore
+	}
+	enc.pageKeys = &keys
+}
+
+// Paginate adds a pagination envelope to be encoded, must be used inside a slice or array encoding (does not encode a key)
+// or as the top level value of the Encoder.
+//
+// The envelope is an object of the form {"items":[...],"next_cursor":"...","has_more":true}.
+// items is called to add the elements of the items array, they are written directly to the Encoder
+// and never collected. If nextCursor is empty, null is written as the cursor, or the cursor key is omitted
+// if SetStripNulls(true) was called.
+func (enc *Encoder) Paginate(items func(enc *Encoder), nextCursor string, hasMore bool) error {
+	keys := &DefaultPaginationKeys
+	if enc.pageKeys != nil {
+		keys = enc.pageKeys
+	}
+	return enc.AddObject(pageEnvelope{keys, items, nextCursor, hasMore})
+}
+
+type pageEnvelope struct {
+	keys       *PaginationKeys
+	items      func(enc *Encoder)
+	nextCursor string
+	hasMore    bool
+}
+
+func (p pageEnvelope) IsNil() bool {
+	return false
+}
+
+func (p pageEnvelope) MarshalObject(enc *Encoder) {
+	enc.AddArrayKey(p.keys.Items, EncodeArrayFunc(p.items))
+	if p.nextCursor == "" {
+		enc.AddNullKey(p.keys.NextCursor)
+	} else {
+		enc.AddStringKey(p.keys.NextCursor, p.nextCursor)
+	}
+	enc.AddBoolKey(p.keys.HasMore, p.hasMore)
+}
@@ -0,0 +1,55 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderPaginate(t *testing.T) {
+	items := []*testVersionedObject{
+		&testVersionedObject{id: 1, name: "a"},
+		&testVersionedObject{id: 2, name: "b"},
+	}
+	testCases := []struct {
+		name       string
+		keys       *PaginationKeys
+		nextCursor string
+		hasMore    bool
+		expected   string
+	}{
+		{
+			name:       "default-keys",
+			nextCursor: "abc",
+			hasMore:    true,
+			expected:   `{"items":[{"id":1,"name":"a"},{"id":2,"name":"b"}],"next_cursor":"abc","has_more":true}`,
+		},
+		{
+			name:     "last-page",
+			expected: `{"items":[{"id":1,"name":"a"},{"id":2,"name":"b"}],"next_cursor":null,"has_more":false}`,
+		},
+		{
+			name:       "custom-keys",
+			keys:       &PaginationKeys{Items: "data", NextCursor: "cursor"},
+			nextCursor: "abc",
+			hasMore:    true,
+			expected:   `{"data":[{"id":1,"name":"a"},{"id":2,"name":"b"}],"cursor":"abc","has_more":true}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enc := NewEncoder()
+			defer enc.addToPool()
+			if testCase.keys != nil {
+				enc.SetPaginationKeys(*testCase.keys)
+			}
+			err := enc.Paginate(func(enc *Encoder) {
+				for _, item := range items {
+					enc.AddObject(item)
+				}
+			}, testCase.nextCursor, testCase.hasMore)
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package gojay
+
+import "sync"
+
+// parallelBufPool holds the scratch buffers MarshalArrayParallel encodes chunks to
+var parallelBufPool = make(chan []byte, 16)
+
+// MarshalArrayParallel returns the JSON encoding of v as MarshalArray does, encoding it on up to workers goroutines.
+//
+// If v implements MarshalerArrayRange, its elements are split in as many chunks as workers,
+// each chunk is encoded by its own Encoder into a scratch buffer borrowed from a pool,
+// then the chunks are stitched together in order. Otherwise, or if workers is less than 2, v is encoded by MarshalArray.
+// A scratch buffer grown above the MaxBufferSize of the PoolConfig is released instead of being pooled.
+// MarshalArrayRange is called concurrently, it must not modify v nor state shared between elements.
+// If several chunks fail, the error of the first one is returned.
+func MarshalArrayParallel(v MarshalerArray, workers int) ([]byte, error) {
+	r, ok := v.(MarshalerArrayRange)
+	if !ok || workers < 2 || isNilArray(v) {
+		return MarshalArray(v)
+	}
+	n := r.Len()
+	if workers > n {
+		workers = n
+	}
+	if workers < 2 {
+		return MarshalArray(v)
+	}
+	chunks := make([][]byte, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := range chunks {
+		// chunk sizes differ by one at most, none is empty as workers is at most n
+		from, to := i*n/workers, (i+1)*n/workers
+		wg.Add(1)
+		go func(i, from, to int) {
+			defer wg.Done()
+			chunks[i], errs[i] = encodeArrayRange(r, from, to)
+		}(i, from, to)
+	}
+	wg.Wait()
+	total := 2
+	for i, chunk := range chunks {
+		if errs[i] != nil {
+			for _, chunk := range chunks {
+				releaseParallelBuf(chunk)
+			}
+			return nil, errs[i]
+		}
+		total += len(chunk) + 1
+	}
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.grow(total)
+	enc.writeOpen('[')
+	for _, chunk := range chunks {
+		if len(chunk) > 0 {
+			if enc.hasValue {
+				enc.writeByte(',')
+			}
+			enc.write(chunk)
+			enc.hasValue = true
+		}
+		releaseParallelBuf(chunk)
+	}
+	enc.writeClose(']')
+	return enc.encoded()
+}
+
+// encodeArrayRange encodes the elements of v from index from to index to, without the brackets of the array.
+func encodeArrayRange(v MarshalerArrayRange, from, to int) ([]byte, error) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	select {
+	case enc.buf = <-parallelBufPool:
+	default:
+		enc.grow(poolConfig.EncoderBufferSize)
+	}
+	enc.enter()
+	v.MarshalArrayRange(enc, from, to)
+	if enc.err != nil {
+		releaseParallelBuf(enc.buf)
+		return nil, enc.err
+	}
+	return enc.buf, nil
+}
+
+func releaseParallelBuf(b []byte) {
+	if b == nil || !poolableBuffer(PoolEncoder, cap(b)) {
+		return
+	}
+	select {
+	case parallelBufPool <- b[:0]:
+	default:
+	}
+}
@@ -0,0 +1,97 @@
+package gojay
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testParallelSlice []*testObject
+
+func (t testParallelSlice) MarshalArray(enc *Encoder) {
+	t.MarshalArrayRange(enc, 0, len(t))
+}
+
+func (t testParallelSlice) MarshalArrayRange(enc *Encoder, from, to int) {
+	for _, e := range t[from:to] {
+		if e == nil {
+			enc.SetError(errTestMarshal)
+			return
+		}
+		enc.AddObject(e)
+	}
+}
+
+func (t testParallelSlice) Len() int {
+	return len(t)
+}
+
+func (t testParallelSlice) IsNil() bool {
+	return t == nil
+}
+
+func TestMarshalArrayParallel(t *testing.T) {
+	v := make(testParallelSlice, 10001)
+	for i := range v {
+		v[i] = &testObject{testStr: "string " + strconv.Itoa(i), testInt: i}
+	}
+	expected, err := MarshalArray(v)
+	assert.Nil(t, err, "Error should be nil")
+	for _, workers := range []int{0, 1, 3, 8, 20000} {
+		r, err := MarshalArrayParallel(v, workers)
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, string(expected), string(r), "Result of marshalling is different as the one expected")
+	}
+
+	for _, size := range [][2]int{{5, 4}, {7, 4}, {9, 8}, {3, 8}, {2, 3}} {
+		v := make(testParallelSlice, size[0])
+		for i := range v {
+			v[i] = &testObject{testInt: i}
+		}
+		expected, err := MarshalArray(v)
+		assert.Nil(t, err, "Error should be nil")
+		r, err := MarshalArrayParallel(v, size[1])
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, string(expected), string(r), "every element should be encoded once whatever the chunk sizes")
+	}
+
+	r, err := MarshalArrayParallel(testParallelSlice{}, 4)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "[]", string(r), "Result of marshalling is different as the one expected")
+	r, err = MarshalArrayParallel(testParallelSlice(nil), 4)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "null", string(r), "Result of marshalling is different as the one expected")
+	r, err = MarshalArrayParallel(EncodeArrayFunc(func(enc *Encoder) { enc.AddInt(1) }), 4)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "[1]", string(r), "arrays not implementing MarshalerArrayRange should be encoded by MarshalArray")
+}
+
+func TestMarshalArrayParallelError(t *testing.T) {
+	v := make(testParallelSlice, 100)
+	for i := range v {
+		v[i] = &testObject{testInt: i}
+	}
+	v[70] = nil
+	r, err := MarshalArrayParallel(v, 4)
+	assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+	assert.Nil(t, r, "result should be nil")
+}
+
+func TestMarshalArrayParallelMaxBufferSize(t *testing.T) {
+	defer SetPoolConfig(DefaultPoolConfig())
+	SetPoolConfig(PoolConfig{Size: 2, EncoderBufferSize: 16, DecoderBufferSize: 8, MaxBufferSize: 256})
+	for len(parallelBufPool) > 0 {
+		<-parallelBufPool
+	}
+	v := make(testParallelSlice, 100)
+	for i := range v {
+		v[i] = &testObject{testStr: "string " + strconv.Itoa(i), testInt: i}
+	}
+	expected, err := MarshalArray(v)
+	assert.Nil(t, err, "Error should be nil")
+	r, err := MarshalArrayParallel(v, 2)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, string(expected), string(r), "Result of marshalling is different as the one expected")
+	assert.Len(t, parallelBufPool, 0, "buffers larger than MaxBufferSize should not be pooled")
+}
@@ -0,0 +1,188 @@
+package gojay
+
+// MarshalObjectPatch returns the JSON merge patch, as defined by RFC 7386, turning the encoding of baseline into the encoding of v.
+//
+// Only the keys whose encoded value differs from baseline are written, keys of baseline missing from v are written as null.
+// Nested objects are diffed recursively, any other value, arrays included, is written whole when it differs.
+// As in any merge patch, a key with a null value in v is indistinguishable from a removed key,
+// null members of objects are therefore compared as missing keys. Nulls inside arrays are kept.
+func MarshalObjectPatch(baseline, v MarshalerObject) ([]byte, error) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	if err := enc.writeObjectPatch(baseline, v); err != nil {
+		return nil, err
+	}
+	return enc.encoded()
+}
+
+// AddObjectKeyPatch adds the JSON merge patch turning the encoding of baseline into the encoding of value,
+// must be used inside an object as it will encode a key
+//
+// See MarshalObjectPatch for the content of the patch. If value is nil, the key is written with a null value.
+func (enc *Encoder) AddObjectKeyPatch(key string, baseline, value MarshalerObject) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddObjectKeyPatch(key, baseline, value) })
+	}
+	if value == nil || value.IsNil() {
+		return enc.AddNullKey(key)
+	}
+	if enc.err != nil {
+		return enc.err
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	if err := enc.writeObjectPatch(baseline, value); err != nil {
+		return err
+	}
+	enc.record(KindObject, start)
+	return enc.err
+}
+
+func (enc *Encoder) writeObjectPatch(baseline, v MarshalerObject) error {
+	base, err := enc.encodeForPatch(baseline)
+	if err != nil {
+		return err
+	}
+	cur, err := enc.encodeForPatch(v)
+	if err != nil {
+		return err
+	}
+	return enc.writePatch(base, cur)
+}
+
+// encodeForPatch encodes v with the encoding settings of enc, so that the encodings can be compared.
+func (enc *Encoder) encodeForPatch(v MarshalerObject) ([]byte, error) {
+	if v == nil || v.IsNil() {
+		return []byte("{}"), nil
+	}
+	sub := NewEncoder()
+	defer sub.addToPool()
+	sub.schemaVersion = enc.schemaVersion
+	sub.sortMapKeys = enc.sortMapKeys
+	sub.escapeTable = enc.escapeTable
+	sub.keyFilter = enc.keyFilter
+	sub.writeOpen('{')
+	v.MarshalObject(sub)
+	sub.writeClose('}')
+	return sub.encoded()
+}
+
+// patchField is a key of an encoded object, as it is escaped in the object, with the raw bytes of its value.
+type patchField struct {
+	key string
+	raw []byte
+}
+
+// writePatch writes the merge patch turning the encoded object base into the encoded object cur.
+// Members with a null value are ignored, as in a merge patch they are the same as missing keys.
+func (enc *Encoder) writePatch(base, cur []byte) error {
+	baseFields, err := patchFields(base)
+	if err != nil {
+		return err
+	}
+	curFields, err := patchFields(cur)
+	if err != nil {
+		return err
+	}
+	baseValues := make(map[string][]byte, len(baseFields))
+	for _, f := range baseFields {
+		baseValues[f.key] = f.raw
+	}
+	seen := make(map[string]struct{}, len(curFields))
+	enc.writeOpen('{')
+	for _, f := range curFields {
+		seen[f.key] = struct{}{}
+		baseRaw, ok := baseValues[f.key]
+		if ok && string(baseRaw) == string(f.raw) {
+			continue
+		}
+		if f.raw[0] == '{' && (!ok || baseRaw[0] == '{') {
+			// added objects are written as a patch of an empty object, leaving out their null members
+			if !ok {
+				baseRaw = []byte("{}")
+			}
+			mark, hasValue := len(enc.buf), enc.hasValue
+			enc.writePatchKey(f.key)
+			if err := enc.writePatch(baseRaw, f.raw); err != nil {
+				return err
+			}
+			// values only differing by their keys order or their null members
+			if ok && enc.buf[len(enc.buf)-2] == '{' {
+				enc.buf = enc.buf[:mark]
+				enc.hasValue = hasValue
+			}
+			continue
+		}
+		enc.writePatchKey(f.key)
+		enc.write(f.raw)
+	}
+	for _, f := range baseFields {
+		if _, ok := seen[f.key]; !ok {
+			enc.writePatchKey(f.key)
+			enc.writeString("null")
+		}
+	}
+	enc.writeClose('}')
+	return nil
+}
+
+// writePatchKey writes key, as it is escaped in the encoded object, followed by a colon.
+func (enc *Encoder) writePatchKey(key string) {
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeString(key)
+	enc.writeObjKey(objKey)
+}
+
+// patchFields returns the keys of the encoded object data, as they are escaped in data, with the raw bytes of their value.
+// Members with a null value are left out.
+func patchFields(data []byte) ([]patchField, error) {
+	dec := newDecoder(nil, 0)
+	defer dec.addToPool()
+	dec.data = data
+	dec.length = len(data)
+	if dec.nextChar() != '{' {
+		return nil, InvalidJSONError("Invalid JSON while computing patch, expected an object")
+	}
+	dec.cursor++
+	var fields []patchField
+	for {
+		switch dec.nextChar() {
+		case '}':
+			return fields, nil
+		case ',':
+			dec.cursor++
+			continue
+		case '"':
+		default:
+			return nil, InvalidJSONError("Invalid JSON while computing patch")
+		}
+		// the key is kept escaped, as nextKey would unescape it in place
+		keyStart := dec.cursor + 1
+		dec.cursor = keyStart
+		if err := dec.skipString(); err != nil {
+			return nil, err
+		}
+		k := data[keyStart : dec.cursor-1]
+		if dec.nextChar() != ':' {
+			return nil, InvalidJSONError("Invalid JSON while computing patch, expected a colon")
+		}
+		dec.cursor++
+		if dec.nextChar() == 0 {
+			return nil, InvalidJSONError("Invalid JSON while computing patch")
+		}
+		start := dec.cursor
+		if err := dec.skipData(); err != nil {
+			return nil, err
+		}
+		if raw := data[start:dec.cursor]; string(raw) != "null" {
+			fields = append(fields, patchField{string(k), raw})
+		}
+	}
+}
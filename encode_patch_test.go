@@ -0,0 +1,152 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPatchAddress struct {
+	city string
+	zip  string
+}
+
+func (a *testPatchAddress) IsNil() bool {
+	return a == nil
+}
+
+func (a *testPatchAddress) MarshalObject(enc *Encoder) {
+	enc.AddStringKey("city", a.city)
+	enc.AddStringKey("zip", a.zip)
+}
+
+type testPatchUser struct {
+	name     string
+	age      int
+	nickname string
+	tags     []string
+	address  *testPatchAddress
+}
+
+func (u *testPatchUser) IsNil() bool {
+	return u == nil
+}
+
+func (u *testPatchUser) MarshalObject(enc *Encoder) {
+	enc.AddStringKey("name", u.name)
+	enc.AddIntKey("age", u.age)
+	if u.nickname != "" {
+		enc.AddStringKey("nickname", u.nickname)
+	}
+	enc.AddArrayKey("tags", EncodeArrayFunc(func(enc *Encoder) {
+		for _, t := range u.tags {
+			enc.AddString(t)
+		}
+	}))
+	enc.AddObjectKey("address", u.address)
+}
+
+func TestMarshalObjectPatch(t *testing.T) {
+	baseline := &testPatchUser{
+		name:     "John",
+		age:      30,
+		nickname: "Jo",
+		tags:     []string{"a", "b"},
+		address:  &testPatchAddress{"Paris", "75001"},
+	}
+	testCases := []struct {
+		name     string
+		baseline MarshalerObject
+		value    *testPatchUser
+		expected string
+	}{
+		{
+			name:     "unchanged",
+			baseline: baseline,
+			value:    &testPatchUser{"John", 30, "Jo", []string{"a", "b"}, &testPatchAddress{"Paris", "75001"}},
+			expected: `{}`,
+		},
+		{
+			name:     "changed-and-removed",
+			baseline: baseline,
+			value:    &testPatchUser{"John", 31, "", []string{"a"}, &testPatchAddress{"Paris", "75002"}},
+			expected: `{"age":31,"tags":["a"],"address":{"zip":"75002"},"nickname":null}`,
+		},
+		{
+			name:     "removed-object",
+			baseline: baseline,
+			value:    &testPatchUser{"John", 30, "Jo", []string{"a", "b"}, nil},
+			expected: `{"address":null}`,
+		},
+		{
+			name:     "added-object",
+			baseline: &testPatchUser{name: "John"},
+			value:    &testPatchUser{name: "John", address: &testPatchAddress{"Lyon", "69001"}},
+			expected: `{"address":{"city":"Lyon","zip":"69001"}}`,
+		},
+		{
+			name:     "no-baseline",
+			baseline: (*testPatchUser)(nil),
+			value:    &testPatchUser{name: "John"},
+			expected: `{"name":"John","age":0,"tags":[]}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r, err := MarshalObjectPatch(testCase.baseline, testCase.value)
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(r), "Result of marshalling is different as the one expected")
+		})
+	}
+}
+
+func TestEncoderAddObjectKeyPatch(t *testing.T) {
+	baseline := &testPatchAddress{"Paris", "75001"}
+	r, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("op", "patch")
+		enc.AddObjectKeyPatch("data", baseline, &testPatchAddress{"Lyon", "75001"})
+		enc.AddObjectKeyPatch("removed", baseline, (*testPatchAddress)(nil))
+		enc.AddObjectKeyPatch("none", baseline, nil)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"op":"patch","data":{"city":"Lyon"},"removed":null,"none":null}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestMarshalObjectPatchNullsAndEscapedKeys(t *testing.T) {
+	baseline := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey(`a"b`, "x")
+		enc.AddStringKey("gone\n", "x")
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddString("a")
+		}))
+		enc.AddNullKey("n")
+		enc.AddObjectKey("obj", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntKey("x", 1)
+		}))
+	})
+	value := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey(`a"b`, "y")
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddNull()
+			enc.AddString("a")
+		}))
+		enc.AddObjectKey("obj", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntKey("x", 1)
+			enc.AddNullKey("y")
+		}))
+		enc.AddObjectKey("added", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddNullKey("z")
+		}))
+	})
+	r, err := MarshalObjectPatch(baseline, value)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"a\"b":"y","arr":[null,"a"],"added":{},"gone\n":null}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
@@ -1,19 +1,106 @@
 package gojay
 
+import "io"
+
 var encObjPool = make(chan *Encoder, 16)
 
+// NewEncoderWriter returns a new encoder or borrows one from the pool,
+// the bytes it encodes are written to w when Flush is called.
+func NewEncoderWriter(w io.Writer) *Encoder {
+	enc := NewEncoder()
+	enc.w = w
+	return enc
+}
+
 // NewEncoder returns a new encoder or borrows one from the pool
 func NewEncoder() *Encoder {
 	select {
 	case enc := <-encObjPool:
+		if m := metrics(); m != nil {
+			m.PoolGet(PoolEncoder, true)
+		}
 		return enc
 	default:
+		if m := metrics(); m != nil {
+			m.PoolGet(PoolEncoder, false)
+		}
 		return &Encoder{}
 	}
 }
 
+// BorrowEncoder borrows an Encoder from the pool, or returns a new one if the pool is empty,
+// so that it can be reused across several encodings, see Reset.
+// It must be given back to the pool with Release once it is not used anymore.
+func BorrowEncoder() *Encoder {
+	return NewEncoder()
+}
+
+// Reset empties the buffer of the Encoder and clears the state of the value being encoded,
+// so that another value can be encoded from scratch with the same settings.
+// The capacity of the buffer is reused, the bytes returned by Bytes before the call are overwritten.
+func (enc *Encoder) Reset() {
+	enc.buf = enc.buf[:0]
+	enc.compact = 0
+	enc.hasValue = false
+	enc.depth = 0
+	enc.flush = arrayFlush{}
+	enc.streaming = false
+	enc.pinned = 0
+	enc.streamErr = nil
+	enc.err = nil
+	enc.written = 0
+	enc.sortFrames = enc.sortFrames[:0]
+	enc.sortMap = false
+	enc.checksums = enc.checksums[:0]
+}
+
+// Release gives the Encoder back to the pool, resetting its buffer and settings.
+// The Encoder must not be used after the call, the bytes returned by Bytes stay valid as the buffer is not reused.
+func (enc *Encoder) Release() {
+	enc.addToPool()
+}
+
 func (enc *Encoder) addToPool() {
 	enc.buf = nil
+	enc.minifyEmbedded = false
+	enc.strCache = nil
+	enc.schemaVersion = 0
+	enc.sortMapKeys = false
+	enc.pageKeys = nil
+	enc.envelope = nil
+	enc.escapeTable = nil
+	enc.stripNulls = false
+	enc.keyFilter = nil
+	enc.fieldHook = nil
+	enc.hookDepth = 0
+	enc.strTransform = nil
+	enc.timeLayout = ""
+	enc.timeFormat = TimeString
+	enc.durationFormat = DurationNanoseconds
+	enc.floatFormat = 0
+	enc.floatPrecision = 0
+	enc.hasFloatPrecision = false
+	enc.nanPolicy = NaNError
+	enc.compact = 0
+	enc.hasValue = false
+	enc.depth = 0
+	enc.w = nil
+	enc.flush = arrayFlush{}
+	enc.trailingNewline = false
+	enc.streaming = false
+	enc.pinned = 0
+	enc.streamErr = nil
+	enc.err = nil
+	enc.indented = false
+	enc.indentPrefix = ""
+	enc.indent = ""
+	enc.written = 0
+	enc.stats = nil
+	enc.sortKeys = false
+	enc.sortFrames = nil
+	enc.sortMap = false
+	enc.canonical = false
+	enc.checksums = nil
 	select {
 	case encObjPool <- enc:
 	default:
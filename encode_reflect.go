@@ -0,0 +1,479 @@
+package gojay
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// reflectFields caches the fields to encode of the struct types encoded by reflection
+var reflectFields sync.Map
+
+// reflectField is a field of a struct encoded by reflection.
+type reflectField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	quoted    bool
+}
+
+// addReflect adds v, encoded by reflection, must be used inside a slice or array encoding (does not encode a key)
+//
+// It is the fallback used by Marshal for the values implementing none of the Marshaler interfaces:
+//   - struct fields are encoded following their json tags as encoding/json does, name, "-", omitempty and string included,
+//     the fields of embedded structs being promoted
+//   - maps with string, integer or encoding.TextMarshaler keys are encoded as objects, their keys sorted if SetSortMapKeys was set
+//   - nil slices, maps and pointers are encoded as null, []byte as a base64 string and time.Time as AddTime does
+//   - values implementing MarshalerObject, MarshalerArray, MarshalerMap, json.Marshaler
+//     or encoding.TextMarshaler are encoded through their methods
+//
+// The fields of each struct type are resolved once and cached.
+// Channels, functions and complex numbers cannot be encoded, an InvalidTypeError is returned,
+// as for NaN and infinite floats unless SetNaNPolicy says otherwise.
+func (enc *Encoder) addReflect(v reflect.Value) error {
+	r := enc.reflectMark()
+	start := enc.offset()
+	enc.writeSep()
+	k, err := enc.writeReflect(v)
+	enc.pinned--
+	if err != nil {
+		return enc.dropReflect(r, err)
+	}
+	if k == KindNull && enc.stripNulls {
+		enc.truncate(r.mark, r.hasValue)
+		return nil
+	}
+	enc.record(k, start)
+	return nil
+}
+
+// addReflectKey adds v, encoded by reflection, with key.
+// If quoted is true, booleans and numbers are encoded as strings as with the string option of json tags.
+func (enc *Encoder) addReflectKey(key string, v reflect.Value, quoted bool) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.addReflectKey(key, v, quoted) })
+	}
+	r := enc.reflectMark()
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	var k Kind
+	var err error
+	if quoted {
+		k, err = enc.writeReflectQuoted(v)
+	} else {
+		k, err = enc.writeReflect(v)
+	}
+	enc.pinned--
+	if err != nil {
+		return enc.dropReflect(r, err)
+	}
+	if k == KindNull && enc.stripNulls {
+		enc.truncate(r.mark, r.hasValue)
+		return nil
+	}
+	enc.record(k, start)
+	return nil
+}
+
+// reflectState is the state of an Encoder before a value encoded by reflection.
+type reflectState struct {
+	mark     int
+	hasValue bool
+	depth    int
+	frames   int
+}
+
+// reflectMark returns the state of enc before a value encoded by reflection is written,
+// the buffer is pinned until the value is written, so that it can be removed if it fails.
+func (enc *Encoder) reflectMark() reflectState {
+	enc.pinned++
+	return reflectState{len(enc.buf), enc.hasValue, enc.depth, len(enc.sortFrames)}
+}
+
+// dropReflect removes the separator, key and partial value written since r, leaving enc in the state it was before,
+// then sets err with SetError and returns it.
+func (enc *Encoder) dropReflect(r reflectState, err error) error {
+	enc.depth = r.depth
+	if len(enc.sortFrames) > r.frames {
+		enc.sortFrames = enc.sortFrames[:r.frames]
+	}
+	enc.truncate(r.mark, r.hasValue)
+	enc.SetError(err)
+	return err
+}
+
+// writeReflect writes v and returns its Kind.
+func (enc *Encoder) writeReflect(v reflect.Value) (Kind, error) {
+	if !v.IsValid() {
+		enc.writeString("null")
+		return KindNull, nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			enc.writeString("null")
+			return KindNull, nil
+		}
+	}
+	if v.Type() == timeType && v.CanInterface() {
+		t := v.Interface().(time.Time)
+		if err := enc.checkTime(t, enc.timeFormat); err != nil {
+			return 0, err
+		}
+		return enc.writeTimeFormat(t, enc.timeFormat, ""), nil
+	}
+	if k, ok, err := enc.writeMarshaler(v); ok {
+		return k, err
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		enc.buf = strconv.AppendBool(enc.buf, v.Bool())
+		return KindBool, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		enc.buf = strconv.AppendInt(enc.buf, v.Int(), 10)
+		return KindNumber, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		enc.buf = strconv.AppendUint(enc.buf, v.Uint(), 10)
+		return KindNumber, nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if err := enc.checkFloat(f); err != nil {
+			return 0, err
+		}
+		return enc.writeFloat(f, v.Type().Bits()), nil
+	case reflect.String:
+		enc.writeByte('"')
+		enc.writeStringValue(v.String())
+		enc.writeByte('"')
+		return KindString, nil
+	case reflect.Slice:
+		if v.IsNil() {
+			enc.writeString("null")
+			return KindNull, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			enc.writeBase64(v.Bytes())
+			return KindString, nil
+		}
+		return KindArray, enc.writeReflectArray(v)
+	case reflect.Array:
+		return KindArray, enc.writeReflectArray(v)
+	case reflect.Map:
+		if v.IsNil() {
+			enc.writeString("null")
+			return KindNull, nil
+		}
+		return KindObject, enc.writeReflectMap(v)
+	case reflect.Struct:
+		return KindObject, enc.writeReflectStruct(v)
+	case reflect.Ptr, reflect.Interface:
+		return enc.writeReflect(v.Elem())
+	}
+	return 0, InvalidTypeError(fmt.Sprintf("Cannot marshal value of type %s", v.Type()))
+}
+
+// writeReflectQuoted writes v as writeReflect does, booleans and numbers being written inside a string.
+func (enc *Encoder) writeReflectQuoted(v reflect.Value) (Kind, error) {
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		enc.writeByte('"')
+		if _, err := enc.writeReflect(v); err != nil {
+			return 0, err
+		}
+		enc.writeByte('"')
+		return KindString, nil
+	}
+	return enc.writeReflect(v)
+}
+
+// writeMarshaler writes v if it, or a pointer to it, implements one of the Marshaler interfaces
+// or is one of the types with a dedicated Add method. ok reports whether v was handled.
+func (enc *Encoder) writeMarshaler(v reflect.Value) (k Kind, ok bool, err error) {
+	if !v.CanInterface() {
+		return 0, false, nil
+	}
+	if k, ok, err = enc.writeMarshalerValue(v.Interface()); ok || !v.CanAddr() {
+		return k, ok, err
+	}
+	return enc.writeMarshalerValue(v.Addr().Interface())
+}
+
+func (enc *Encoder) writeMarshalerValue(i interface{}) (Kind, bool, error) {
+	switch vt := i.(type) {
+	case MarshalerObject:
+		return enc.writeObject(vt), true, enc.err
+	case MarshalerArray:
+		return enc.writeArray(vt), true, enc.err
+	case MarshalerMap:
+		enc.writeByte('{')
+		enc.enterMap()
+		vt.MarshalMap(enc)
+		enc.writeClose('}')
+		return KindObject, true, enc.err
+	case json.Number:
+		s, err := numberLiteral(vt)
+		if err != nil {
+			return 0, true, err
+		}
+		enc.writeString(s)
+		return KindNumber, true, nil
+	case *big.Int:
+		enc.buf = vt.Append(enc.buf, 10)
+		return KindNumber, true, nil
+	case *big.Float:
+		if vt.IsInf() {
+			return 0, true, InvalidTypeError("Cannot marshal an infinite big.Float")
+		}
+		enc.buf = vt.Append(enc.buf, 'f', -1)
+		return KindNumber, true, nil
+	case json.Marshaler:
+		raw, err := marshalJSON(vt)
+		if err != nil {
+			return 0, true, err
+		}
+		enc.write(raw)
+		return embeddedKind(raw), true, nil
+	case encoding.TextMarshaler:
+		text, err := vt.MarshalText()
+		if err != nil {
+			return 0, true, err
+		}
+		enc.writeByte('"')
+		enc.writeStringValue(string(text))
+		enc.writeByte('"')
+		return KindString, true, nil
+	}
+	return 0, false, nil
+}
+
+func (enc *Encoder) writeReflectArray(v reflect.Value) error {
+	enc.writeOpen('[')
+	for i := 0; i < v.Len(); i++ {
+		if err := enc.addReflect(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	enc.writeClose(']')
+	return nil
+}
+
+func (enc *Encoder) writeReflectMap(v reflect.Value) error {
+	var keyString func(k reflect.Value) (string, error)
+	switch kt := v.Type().Key(); {
+	case kt.Kind() == reflect.String:
+		keyString = func(k reflect.Value) (string, error) { return k.String(), nil }
+	case kt.Implements(textMarshalerType):
+		keyString = func(k reflect.Value) (string, error) {
+			if k.Kind() == reflect.Ptr && k.IsNil() {
+				return "", nil
+			}
+			text, err := k.Interface().(encoding.TextMarshaler).MarshalText()
+			return string(text), err
+		}
+	case isIntKind(kt.Kind()):
+		keyString = func(k reflect.Value) (string, error) { return strconv.FormatInt(k.Int(), 10), nil }
+	case isUintKind(kt.Kind()):
+		keyString = func(k reflect.Value) (string, error) { return strconv.FormatUint(k.Uint(), 10), nil }
+	default:
+		return InvalidTypeError(fmt.Sprintf("Cannot marshal map with keys of type %s", v.Type().Key()))
+	}
+	enc.writeByte('{')
+	enc.enterMap()
+	iter := v.MapRange()
+	for iter.Next() {
+		key, err := keyString(iter.Key())
+		if err != nil {
+			return err
+		}
+		if err := enc.addReflectKey(key, iter.Value(), false); err != nil {
+			return err
+		}
+	}
+	enc.writeClose('}')
+	return nil
+}
+
+func (enc *Encoder) writeReflectStruct(v reflect.Value) error {
+	enc.writeOpen('{')
+	for _, f := range cachedFields(v.Type()) {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || (f.omitEmpty && isEmptyValue(fv)) {
+			continue
+		}
+		if err := enc.addReflectKey(f.name, fv, f.quoted); err != nil {
+			return err
+		}
+	}
+	enc.writeClose('}')
+	return nil
+}
+
+// fieldByIndex returns the field of v at index, ok is false if it is in an embedded struct through a nil pointer.
+func fieldByIndex(v reflect.Value, index []int) (f reflect.Value, ok bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// isEmptyValue reports whether v is empty as defined by the omitempty option of encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// cachedFields returns the fields to encode of the struct type t.
+func cachedFields(t reflect.Type) []reflectField {
+	if f, ok := reflectFields.Load(t); ok {
+		return f.([]reflectField)
+	}
+	f, _ := reflectFields.LoadOrStore(t, typeFields(t))
+	return f.([]reflectField)
+}
+
+// typeFields lists the fields to encode of the struct type t, in the order encoding/json encodes them.
+//
+// The fields of embedded structs without a json name are promoted. When several fields have the same name,
+// the least nested one is kept, the tagged one among the least nested, none if it remains ambiguous.
+func typeFields(t reflect.Type) []reflectField {
+	type candidate struct {
+		reflectField
+		depth  int
+		tagged bool
+	}
+	var candidates []candidate
+	var walk func(t reflect.Type, index []int, visited map[reflect.Type]bool)
+	walk = func(t reflect.Type, index []int, visited map[reflect.Type]bool) {
+		visited[t] = true
+		defer delete(visited, t)
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			tag := sf.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts := tag, ""
+			if comma := strings.IndexByte(tag, ','); comma >= 0 {
+				name, opts = tag[:comma], tag[comma:]
+			}
+			fieldIndex := append(index[:len(index):len(index)], i)
+			exported := sf.PkgPath == ""
+			if sf.Anonymous && name == "" {
+				ft := sf.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					if !visited[ft] {
+						walk(ft, fieldIndex, visited)
+					}
+					continue
+				}
+			}
+			if !exported {
+				continue
+			}
+			f := candidate{
+				reflectField: reflectField{
+					name:      name,
+					index:     fieldIndex,
+					omitEmpty: strings.Contains(opts, ",omitempty"),
+					quoted:    strings.Contains(opts, ",string"),
+				},
+				depth:  len(fieldIndex),
+				tagged: name != "",
+			}
+			if f.name == "" {
+				f.name = sf.Name
+			}
+			candidates = append(candidates, f)
+		}
+	}
+	walk(t, nil, map[reflect.Type]bool{})
+
+	fields := make([]reflectField, 0, len(candidates))
+	for _, c := range candidates {
+		dominant := true
+		for _, o := range candidates {
+			if o.name != c.name || sameIndex(o.index, c.index) {
+				continue
+			}
+			if o.depth < c.depth || o.depth == c.depth && (o.tagged || !c.tagged) {
+				dominant = false
+				break
+			}
+		}
+		if dominant {
+			fields = append(fields, c.reflectField)
+		}
+	}
+	return fields
+}
+
+func sameIndex(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUintKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package gojay
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testReflectBase struct {
+	ID      int    `json:"id"`
+	Shadow  string `json:"name"`
+	Created time.Time
+}
+
+type testReflectInner struct {
+	Deep bool `json:"deep"`
+}
+
+type testReflectPtrBase struct {
+	Extra string
+}
+
+type testReflectStruct struct {
+	testReflectBase
+	*testReflectPtrBase
+	Name     string                 `json:"name"`
+	Hidden   string                 `json:"-"`
+	Dash     string                 `json:"-,"`
+	Empty    string                 `json:"empty,omitempty"`
+	Count    int64                  `json:"count,string"`
+	Ratio    *float64               `json:"ratio,string"`
+	Unsigned uint64                 `json:"unsigned"`
+	Float32  float32                `json:"float32"`
+	Bytes    []byte                 `json:"bytes"`
+	NilSlice []int                  `json:"nilSlice"`
+	Ints     [2]int                 `json:"ints"`
+	Inner    *testReflectInner      `json:"inner"`
+	NilPtr   *testReflectInner      `json:"nilPtr"`
+	NilOmit  *testReflectInner      `json:"nilOmit,omitempty"`
+	Map      map[string]interface{} `json:"map"`
+	IntMap   map[int]string         `json:"intMap"`
+	Iface    interface{}            `json:"iface"`
+	Number   json.Number            `json:"number"`
+	unexp    int
+}
+
+func TestEncoderReflect(t *testing.T) {
+	ratio := 0.5
+	v := &testReflectStruct{
+		testReflectBase: testReflectBase{
+			ID:      1,
+			Shadow:  "shadowed",
+			Created: time.Date(2018, 5, 1, 12, 30, 0, 5, time.UTC),
+		},
+		Name:     "a \"name\"",
+		Hidden:   "hidden",
+		Dash:     "dash",
+		Count:    42,
+		Ratio:    &ratio,
+		Unsigned: math.MaxUint64,
+		Float32:  0.1,
+		Bytes:    []byte("hello"),
+		Ints:     [2]int{1, 2},
+		Inner:    &testReflectInner{true},
+		Map:      map[string]interface{}{"a": []interface{}{1, "b", nil}},
+		IntMap:   map[int]string{3: "c"},
+		Iface:    testReflectInner{false},
+		Number:   "1.50",
+		unexp:    1,
+	}
+	expected, err := json.Marshal(v)
+	assert.Nil(t, err, "Error should be nil")
+	r, err := Marshal(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, string(expected), string(r), "Result of marshalling should be the one of encoding/json")
+
+	// embedded pointer set
+	v.testReflectPtrBase = &testReflectPtrBase{"extra"}
+	expected, _ = json.Marshal(v)
+	r, err = Marshal(*v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, string(expected), string(r), "Result of marshalling should be the one of encoding/json")
+}
+
+func TestEncoderReflectValues(t *testing.T) {
+	type status string
+	testCases := []struct {
+		name     string
+		v        interface{}
+		expected string
+	}{
+		{name: "named-string", v: status("ok"), expected: `"ok"`},
+		{name: "slice", v: []string{"a", "b"}, expected: `["a","b"]`},
+		{name: "nil-slice", v: []int(nil), expected: `null`},
+		{name: "map", v: map[string]int{"a": 1}, expected: `{"a":1}`},
+		{name: "nil-map", v: map[string]int(nil), expected: `null`},
+		{name: "pointer", v: &[]int{1}, expected: `[1]`},
+		{name: "time", v: time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC), expected: `"2018-05-01T00:00:00Z"`},
+		{
+			name:     "marshalers",
+			v:        []interface{}{&testObject{testStr: "a"}, testMap{"b": 2}, EncodeArrayFunc(func(enc *Encoder) { enc.AddInt(1) })},
+			expected: `[{"testStr":"a","testInt":0,"testInt64":0,"testInt32":0,"testInt16":0,"testInt8":0,"testUint64":0,"testUint32":0,"testUint16":0,"testUint8":0,"testFloat64":0,"testFloat32":0,"testBool":false},{"b":2},[1]]`,
+		},
+		{name: "nil-marshaler", v: []*testObject{nil}, expected: `[null]`},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r, err := Marshal(testCase.v)
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(r), "Result of marshalling is different as the one expected")
+		})
+	}
+}
+
+func TestEncoderReflectSortMapKeys(t *testing.T) {
+	v := map[string]interface{}{"b": 1, "a": map[string]int{"d": 1, "c": 2}, "é": 3, "B": 4}
+	// encoding/json always sorts map keys
+	expected, _ := json.Marshal(v)
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetSortMapKeys(true)
+	err := enc.addReflect(reflect.ValueOf(v))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, string(expected), string(enc.Bytes()), "map keys should be sorted")
+}
+
+func TestEncoderReflectErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		v    interface{}
+	}{
+		{name: "chan", v: make(chan int)},
+		{name: "func", v: func() {}},
+		{name: "nan", v: struct{ F float64 }{math.NaN()}},
+		{name: "inf", v: []float64{math.Inf(1)}},
+		{name: "complex", v: complex(1, 2)},
+		{name: "map-key", v: map[bool]int{true: 1}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := Marshal(testCase.v)
+			assert.NotNil(t, err, "Error should not be nil")
+			assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+		})
+	}
+	_, err := Marshal(nil)
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	_, err = Marshal([]interface{}{make(chan int), 2})
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	_, err = Marshal(map[string]interface{}{"a": make(chan int)})
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+}
+
+func TestEncoderReflectErrorsRollback(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.writeArray(EncodeArrayFunc(func(enc *Encoder) {
+		enc.AddInt(1)
+		err := enc.addReflect(reflect.ValueOf(make(chan int)))
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+		enc.AddInt(2)
+	}))
+	assert.IsType(t, InvalidTypeError(""), enc.Err(), "error should be set on the encoder")
+	assert.Equal(t, `[1,2]`, string(enc.buf), "failed element should be removed")
+
+	enc = NewEncoder()
+	defer enc.addToPool()
+	enc.writeObject(EncodeObjectFunc(func(enc *Encoder) {
+		err := enc.addReflectKey("a", reflect.ValueOf(struct{ F []float64 }{[]float64{1, math.NaN()}}), false)
+		assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+		enc.AddIntKey("b", 1)
+	}))
+	assert.IsType(t, InvalidTypeError(""), enc.Err(), "error should be set on the encoder")
+	assert.Equal(t, `{"b":1}`, string(enc.buf), "failed key should be removed")
+}
@@ -0,0 +1,79 @@
+package gojay
+
+// RingBufferSink is an io.Writer keeping only the last bytes written to it, up to a fixed capacity.
+// Once full, writing to it overwrites the oldest bytes instead of growing.
+//
+// It can be used as the writer of an Encoder created with NewEncoderWriter,
+// to keep the tail of what was encoded with a hard memory cap.
+// It is not safe for concurrent use.
+type RingBufferSink struct {
+	buf     []byte
+	start   int
+	n       int
+	dropped int
+}
+
+// NewRingBufferSink returns a new RingBufferSink keeping at most capacity bytes.
+func NewRingBufferSink(capacity int) *RingBufferSink {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &RingBufferSink{buf: make([]byte, capacity)}
+}
+
+// Write writes p to the ring buffer, dropping its oldest bytes if there is not enough room.
+// It always returns len(p), nil.
+func (r *RingBufferSink) Write(p []byte) (int, error) {
+	l := len(p)
+	c := len(r.buf)
+	if l >= c {
+		// only the last bytes of p fit
+		r.dropped += r.n + l - c
+		copy(r.buf, p[l-c:])
+		r.start = 0
+		r.n = c
+		return l, nil
+	}
+	if over := r.n + l - c; over > 0 {
+		r.start = (r.start + over) % c
+		r.n -= over
+		r.dropped += over
+	}
+	end := (r.start + r.n) % c
+	k := copy(r.buf[end:], p)
+	copy(r.buf, p[k:])
+	r.n += l
+	return l, nil
+}
+
+// Bytes returns a copy of the bytes held by the ring buffer, from the oldest to the most recent one.
+func (r *RingBufferSink) Bytes() []byte {
+	b := make([]byte, r.n)
+	k := copy(b, r.buf[r.start:minInt(r.start+r.n, len(r.buf))])
+	copy(b[k:], r.buf[:r.n-k])
+	return b
+}
+
+// Len returns the number of bytes held by the ring buffer.
+func (r *RingBufferSink) Len() int {
+	return r.n
+}
+
+// Dropped returns the number of bytes overwritten since the ring buffer was created or reset.
+func (r *RingBufferSink) Dropped() int {
+	return r.dropped
+}
+
+// Reset empties the ring buffer.
+func (r *RingBufferSink) Reset() {
+	r.start = 0
+	r.n = 0
+	r.dropped = 0
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,45 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingBufferSink(t *testing.T) {
+	r := NewRingBufferSink(8)
+	r.Write([]byte("abc"))
+	assert.Equal(t, "abc", string(r.Bytes()), "ring buffer should hold the bytes written")
+	r.Write([]byte("defgh"))
+	assert.Equal(t, "abcdefgh", string(r.Bytes()), "ring buffer should be full")
+	assert.Equal(t, 0, r.Dropped(), "no byte should have been dropped")
+	r.Write([]byte("ij"))
+	assert.Equal(t, "cdefghij", string(r.Bytes()), "oldest bytes should be dropped")
+	assert.Equal(t, 2, r.Dropped(), "2 bytes should have been dropped")
+	r.Write([]byte("klmnopqrstu"))
+	assert.Equal(t, "nopqrstu", string(r.Bytes()), "only the last bytes should be kept")
+	assert.Equal(t, 13, r.Dropped(), "13 bytes should have been dropped")
+	assert.Equal(t, 8, r.Len(), "ring buffer should be full")
+	r.Reset()
+	assert.Equal(t, "", string(r.Bytes()), "ring buffer should be empty")
+	r.Write([]byte("vw"))
+	assert.Equal(t, "vw", string(r.Bytes()), "ring buffer should hold the bytes written")
+}
+
+func TestRingBufferSinkEncoder(t *testing.T) {
+	r := NewRingBufferSink(64)
+	enc := NewEncoderWriter(r)
+	defer enc.addToPool()
+	for i := 0; i < 20; i++ {
+		enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntKey("event", i)
+			enc.AddStringKey("msg", "done")
+		}))
+		enc.Flush()
+	}
+	b := string(r.Bytes())
+	assert.Equal(t, 64, len(b), "ring buffer should be full")
+	assert.True(t, strings.HasSuffix(b, `{"event":18,"msg":"done"},{"event":19,"msg":"done"}`), "ring buffer should hold the last events")
+	assert.True(t, r.Dropped() > 0, "oldest events should have been dropped")
+}
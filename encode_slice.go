@@ -0,0 +1,67 @@
+//go:build go1.18
+
+package gojay
+
+import "reflect"
+
+// Ordered is the set of types whose slices can be encoded with AddSlice and AddSliceKey,
+// the integers, floats and strings, and the types derived from them.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// EncodeSlice adds s to be encoded as an array of objects, must be used inside a slice or array encoding (does not encode a key)
+// It saves declaring a slice type implementing MarshalerArray. nil elements are skipped as with AddObject.
+func EncodeSlice[T MarshalerObject](enc *Encoder, s []T) error {
+	return enc.AddArray(EncodeArrayFunc(func(enc *Encoder) {
+		for _, v := range s {
+			enc.AddObject(v)
+		}
+	}))
+}
+
+// EncodeSliceKey adds s to be encoded as an array of objects, must be used inside an object as it will encode a key
+// It saves declaring a slice type implementing MarshalerArray. nil elements are skipped as with AddObject.
+func EncodeSliceKey[T MarshalerObject](enc *Encoder, key string, s []T) error {
+	return enc.AddArrayKey(key, EncodeArrayFunc(func(enc *Encoder) {
+		for _, v := range s {
+			enc.AddObject(v)
+		}
+	}))
+}
+
+// AddSlice adds s to be encoded as an array of numbers or strings, must be used inside a slice or array encoding (does not encode a key)
+// It saves declaring a slice type implementing MarshalerArray.
+func AddSlice[T Ordered](enc *Encoder, s []T) error {
+	return enc.AddArray(EncodeArrayFunc(func(enc *Encoder) {
+		for _, v := range s {
+			enc.addOrdered(v)
+		}
+	}))
+}
+
+// AddSliceKey adds s to be encoded as an array of numbers or strings, must be used inside an object as it will encode a key
+// It saves declaring a slice type implementing MarshalerArray.
+func AddSliceKey[T Ordered](enc *Encoder, key string, s []T) error {
+	return enc.AddArrayKey(key, EncodeArrayFunc(func(enc *Encoder) {
+		for _, v := range s {
+			enc.addOrdered(v)
+		}
+	}))
+}
+
+// addOrdered adds v, a value of a type of Ordered, the types without a dedicated Add method being encoded by reflection.
+func (enc *Encoder) addOrdered(v interface{}) error {
+	switch vt := v.(type) {
+	case string:
+		return enc.AddString(vt)
+	case int:
+		return enc.AddInt(vt)
+	case float64:
+		return enc.AddFloat(vt)
+	}
+	return enc.addReflect(reflect.ValueOf(v))
+}
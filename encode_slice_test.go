@@ -0,0 +1,39 @@
+//go:build go1.18
+
+package gojay
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSliceLevel string
+
+func TestEncoderSlice(t *testing.T) {
+	objs := []*testObject{{testStr: "a"}, nil, {testStr: "b"}}
+	single, _ := MarshalObject(objs[0])
+	other, _ := MarshalObject(objs[2])
+	r, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		EncodeSliceKey(enc, "objs", objs)
+		AddSliceKey(enc, "strs", []string{"a", `"b"`})
+		AddSliceKey(enc, "ints", []int{1, -2})
+		AddSliceKey(enc, "uints", []uint64{math.MaxUint64})
+		AddSliceKey(enc, "floats", []float32{0.1, 2})
+		AddSliceKey(enc, "levels", []testSliceLevel{"info"})
+		AddSliceKey(enc, "nil", []int(nil))
+		enc.AddArrayKey("nested", EncodeArrayFunc(func(enc *Encoder) {
+			EncodeSlice(enc, objs[:1])
+			AddSlice(enc, []float64{1.5})
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"objs":[`+string(single)+`,null,`+string(other)+`],"strs":["a","\"b\""],"ints":[1,-2],`+
+			`"uints":[18446744073709551615],"floats":[0.1,2],"levels":["info"],"nil":[],`+
+			`"nested":[[`+string(single)+`],[1.5]]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
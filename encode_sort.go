@@ -0,0 +1,114 @@
+package gojay
+
+import "sort"
+
+// SetSortKeys sets whether the keys of every object encoded must be sorted,
+// so that the output is byte-for-byte reproducible whatever the order keys are added in, for caching or signing.
+//
+// Fields are sorted in the byte-wise order of their encoded keys once their object is closed,
+// by moving them within the buffer. An object whose beginning has already been flushed with Flush is not sorted.
+// Unlike SetSortMapKeys, it applies to all objects, at the cost of sorting each of them.
+func (enc *Encoder) SetSortKeys(sort bool) {
+	enc.sortKeys = sort
+}
+
+// sortFrame is an object or an array being encoded with sorted keys.
+type sortFrame struct {
+	// start is the offset of the opening char
+	start  int
+	object bool
+	// fields are the offsets of the start of each field, after its separator
+	fields []int
+}
+
+// sorting reports whether the objects and arrays opened are tracked in sortFrames,
+// either because SetSortKeys was set or because a map with sorted keys is being encoded.
+func (enc *Encoder) sorting() bool {
+	return enc.sortKeys || len(enc.sortFrames) > 0
+}
+
+// pushSortFrame is called once an object or an array has been opened.
+// Only the fields of objects are sorted, and with SetSortMapKeys alone only those of maps.
+func (enc *Encoder) pushSortFrame() {
+	enc.sortFrames = append(enc.sortFrames, sortFrame{
+		start:  enc.offset() - 1,
+		object: enc.buf[len(enc.buf)-1] == '{' && (enc.sortKeys || enc.sortMap),
+	})
+}
+
+// recordField is called once the separator of a new value has been written.
+func (enc *Encoder) recordField() {
+	if n := len(enc.sortFrames); n > 0 && enc.sortFrames[n-1].object {
+		enc.sortFrames[n-1].fields = append(enc.sortFrames[n-1].fields, enc.offset())
+	}
+}
+
+// dropFields forgets the fields of the current object starting at or after the end of the buffer,
+// it is called when the buffer is truncated.
+func (enc *Encoder) dropFields() {
+	if n := len(enc.sortFrames); n > 0 {
+		f := &enc.sortFrames[n-1]
+		for len(f.fields) > 0 && f.fields[len(f.fields)-1] >= enc.offset() {
+			f.fields = f.fields[:len(f.fields)-1]
+		}
+	}
+}
+
+// popSortFrame is called before an object or an array is closed, it sorts the fields of objects.
+func (enc *Encoder) popSortFrame() {
+	n := len(enc.sortFrames)
+	if n == 0 {
+		return
+	}
+	f := enc.sortFrames[n-1]
+	enc.sortFrames = enc.sortFrames[:n-1]
+	if !f.object || len(f.fields) < 2 || f.start < enc.written {
+		return
+	}
+	segs := make([][]byte, len(f.fields))
+	for i, off := range f.fields {
+		end := len(enc.buf)
+		if i+1 < len(f.fields) {
+			// exclude the comma separating the next field
+			end = f.fields[i+1] - enc.written - 1
+		}
+		segs[i] = enc.buf[off-enc.written : end]
+	}
+	less := func(i, j int) bool {
+		return string(fieldKey(segs[i])) < string(fieldKey(segs[j]))
+	}
+	if enc.canonical {
+		less = func(i, j int) bool {
+			return utf16Less(unescapeKey(fieldKey(segs[i])), unescapeKey(fieldKey(segs[j])))
+		}
+	}
+	sort.SliceStable(segs, less)
+	begin := f.fields[0] - enc.written
+	sorted := make([]byte, 0, len(enc.buf)-begin)
+	for i, seg := range segs {
+		if i > 0 {
+			sorted = append(sorted, ',')
+		}
+		sorted = append(sorted, seg...)
+	}
+	copy(enc.buf[begin:], sorted)
+}
+
+// fieldKey returns the encoded key of the field starting seg, leading whitespace excluded.
+func fieldKey(seg []byte) []byte {
+	i := 0
+	for i < len(seg) && seg[i] != '"' {
+		i++
+	}
+	start := i + 1
+	for i = start; i < len(seg); i++ {
+		if seg[i] == '\\' {
+			i++
+			continue
+		}
+		if seg[i] == '"' {
+			return seg[start:i]
+		}
+	}
+	return seg[start:]
+}
@@ -0,0 +1,106 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderSortKeys(t *testing.T) {
+	v := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("zeta", "z,\"}")
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddInt(3)
+			enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+				enc.AddIntKey("b", 2)
+				enc.AddIntKey("a", 1)
+			}))
+			enc.AddInt(1)
+		}))
+		enc.AddObjectKey("obj", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddBoolKey("y", true)
+			enc.AddEmbeddedJSONKey("x", EmbeddedJSON(`{"d":1,"c":2}`))
+		}))
+		enc.AddNullKey("a\"b")
+		enc.AddIntKey("alpha", 1)
+	})
+	testCases := []struct {
+		name     string
+		indent   bool
+		expected string
+	}{
+		{
+			name:     "compact",
+			expected: `{"a\"b":null,"alpha":1,"arr":[3,{"a":1,"b":2},1],"obj":{"x":{"d":1,"c":2},"y":true},"zeta":"z,\"}"}`,
+		},
+		{
+			name:   "indented",
+			indent: true,
+			expected: "{\n" +
+				" \"a\\\"b\": null,\n" +
+				" \"alpha\": 1,\n" +
+				" \"arr\": [\n" +
+				"  3,\n" +
+				"  {\n" +
+				"   \"a\": 1,\n" +
+				"   \"b\": 2\n" +
+				"  },\n" +
+				"  1\n" +
+				" ],\n" +
+				" \"obj\": {\n" +
+				"  \"x\": {\"d\":1,\"c\":2},\n" +
+				"  \"y\": true\n" +
+				" },\n" +
+				" \"zeta\": \"z,\\\"}\"\n" +
+				"}",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enc := NewEncoder()
+			defer enc.addToPool()
+			enc.SetSortKeys(true)
+			// keys are only escaped with an escape table
+			enc.SetEscapeTable(DefaultEscapeTable())
+			if testCase.indent {
+				enc.SetIndent("", " ")
+			}
+			err := enc.AddObject(v)
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+		})
+	}
+}
+
+func TestEncoderSortKeysEmbeddedError(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetSortKeys(true)
+	enc.SetMinifyEmbedded(true)
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddIntKey("b", 2)
+		enc.AddEmbeddedJSONKey("c", EmbeddedJSON(`{"unterminated`))
+		enc.AddIntKey("a", 1)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"a":1,"b":2}`, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+}
+
+func TestEncoderSortKeysFlushed(t *testing.T) {
+	w := &testFlushWriter{}
+	enc := NewEncoderWriter(w)
+	defer enc.addToPool()
+	enc.SetSortKeys(true)
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddIntKey("b", 2)
+		enc.Flush()
+		enc.AddObjectKey("c", EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddIntKey("z", 1)
+			enc.AddIntKey("y", 2)
+		}))
+		enc.AddIntKey("a", 1)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Nil(t, enc.Flush(), "Error should be nil")
+	assert.Equal(t, `{"b":2,"c":{"y":2,"z":1},"a":1}`, w.String(), "objects flushed before being closed should not be sorted")
+}
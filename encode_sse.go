@@ -0,0 +1,75 @@
+package gojay
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+)
+
+// sseBufPool holds the scratch buffers EncodeSSE encodes events to
+var sseBufPool = make(chan []byte, 16)
+
+// EncodeSSE writes v to w as a Server-Sent Event: an "event:" line with event, omitted if event is empty,
+// then the JSON encoding of v on a "data:" line, followed by the blank line ending the event.
+// w is then flushed if it implements http.Flusher, so that the event is sent right away.
+//
+// v is encoded to a scratch buffer borrowed from a pool and given back once written, so that no memory
+// is allocated once the buffers have grown to the size of the events. As strings are escaped, the encoding of v
+// holds no new line, except in EmbeddedJSON values, whose lines are then each written on their own "data:" line.
+// If event holds a new line, an InvalidTypeError is returned and nothing is written.
+func EncodeSSE(w http.ResponseWriter, event string, v MarshalerObject) error {
+	if strings.ContainsAny(event, "\r\n") {
+		return InvalidTypeError("Cannot encode SSE, event name contains a new line")
+	}
+	enc := NewEncoder()
+	defer enc.addToPool()
+	select {
+	case enc.buf = <-sseBufPool:
+	default:
+	}
+	if event != "" {
+		enc.writeString("event: ")
+		enc.writeString(event)
+		enc.writeByte('\n')
+	}
+	enc.writeString("data: ")
+	start := len(enc.buf)
+	enc.writeObject(v)
+	if enc.err != nil {
+		releaseSSEBuf(enc.buf)
+		return enc.err
+	}
+	if bytes.IndexByte(enc.buf[start:], '\n') >= 0 || bytes.IndexByte(enc.buf[start:], '\r') >= 0 {
+		enc.buf = splitSSEData(enc.buf, start)
+	}
+	enc.writeString("\n\n")
+	_, err := w.Write(enc.buf)
+	releaseSSEBuf(enc.buf)
+	if err != nil {
+		return err
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// splitSSEData rewrites the data written in b from start so that each of its lines is on its own "data:" line.
+func splitSSEData(b []byte, start int) []byte {
+	data := string(b[start:])
+	b = b[:start]
+	for i, line := range strings.Split(strings.Replace(data, "\r\n", "\n", -1), "\n") {
+		if i > 0 {
+			b = append(b, "\ndata: "...)
+		}
+		b = append(b, strings.TrimSuffix(line, "\r")...)
+	}
+	return b
+}
+
+func releaseSSEBuf(b []byte) {
+	select {
+	case sseBufPool <- b[:0]:
+	default:
+	}
+}
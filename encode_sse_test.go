@@ -0,0 +1,35 @@
+package gojay
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeSSE(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := EncodeSSE(w, "update", &testLineEvent{1, "a\nb"})
+	assert.Nil(t, err, "Error should be nil")
+	err = EncodeSSE(w, "", EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddEmbeddedJSONKey("raw", EmbeddedJSON{'[', '1', ',', '\n', '2', ']'})
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		"event: update\ndata: {\"id\":1,\"name\":\"a\\nb\"}\n\ndata: {\"raw\":[1,\ndata: 2]}\n\n",
+		w.Body.String(),
+		"Result of marshalling is different as the one expected")
+	assert.True(t, w.Flushed, "response should be flushed")
+}
+
+func TestEncodeSSEErrors(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := EncodeSSE(w, "a\nb", &testLineEvent{1, "a"})
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	err = EncodeSSE(w, "a", EncodeObjectFunc(func(enc *Encoder) {
+		enc.SetError(errTestMarshal)
+	}))
+	assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+	assert.Equal(t, "", w.Body.String(), "nothing should be written")
+}
@@ -0,0 +1,46 @@
+package gojay
+
+// Stats holds, by Kind, the number of values added to an Encoder and the number of bytes written for them.
+//
+// Bytes of a value include its separator and key, bytes of objects and arrays include their content,
+// which is therefore also counted in the Kinds of the values they hold.
+type Stats struct {
+	Counts [KindNull + 1]int
+	Bytes  [KindNull + 1]int
+}
+
+// SetCollectStats sets whether the Encoder should collect the Stats of the values added to it.
+// Collection is disabled by default, disabling it resets the Stats collected so far.
+func (enc *Encoder) SetCollectStats(collect bool) {
+	if !collect {
+		enc.stats = nil
+	} else if enc.stats == nil {
+		enc.stats = &Stats{}
+	}
+}
+
+// Stats returns the Stats collected by the Encoder since SetCollectStats(true) was called.
+func (enc *Encoder) Stats() Stats {
+	if enc.stats == nil {
+		return Stats{}
+	}
+	return *enc.stats
+}
+
+// offset returns the number of bytes encoded so far, flushed ones included.
+func (enc *Encoder) offset() int {
+	return enc.written + len(enc.buf)
+}
+
+// record adds a value of kind k added from offset start to the Stats, if they are collected.
+// It is called once a value has been entirely written, the buffer is then flushed if it is being streamed.
+func (enc *Encoder) record(k Kind, start int) {
+	if enc.streaming {
+		enc.streamFlush()
+	}
+	if enc.stats == nil || k == 0 {
+		return
+	}
+	enc.stats.Counts[k]++
+	enc.stats.Bytes[k] += enc.offset() - start
+}
@@ -0,0 +1,67 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoderStats(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetCollectStats(true)
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("name", "ab")
+		enc.AddIntKey("n", 12)
+		enc.AddBoolKey("ok", true)
+		enc.AddNullKey("none")
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddInt(1)
+			enc.AddString("x")
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"name":"ab","n":12,"ok":true,"none":null,"arr":[1,"x"]}`, string(enc.Bytes()))
+	stats := enc.Stats()
+	assert.Equal(
+		t,
+		[KindNull + 1]int{KindString: 2, KindNumber: 2, KindBool: 1, KindObject: 1, KindArray: 1, KindNull: 1},
+		stats.Counts,
+		"counts should be the expected ones",
+	)
+	assert.Equal(
+		t,
+		[KindNull + 1]int{KindString: 15, KindNumber: 8, KindBool: 10, KindObject: 56, KindArray: 14, KindNull: 12},
+		stats.Bytes,
+		"bytes should be the expected ones",
+	)
+
+	enc.SetCollectStats(false)
+	enc.AddString("more")
+	assert.Equal(t, Stats{}, enc.Stats(), "stats should be reset once disabled")
+}
+
+func TestEncoderStatsDisabled(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.AddString("value")
+	assert.Equal(t, Stats{}, enc.Stats(), "stats should not be collected by default")
+}
+
+func TestEncoderStatsFlushed(t *testing.T) {
+	w := &testFlushWriter{}
+	enc := NewEncoderWriter(w)
+	defer enc.addToPool()
+	enc.SetCollectStats(true)
+	err := enc.AddArrayFlushing(EncodeArrayFunc(func(enc *Encoder) {
+		for i := 0; i < 10; i++ {
+			enc.AddEmbeddedJSON(EmbeddedJSON(` {"a":1}`))
+		}
+	}), 3)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Nil(t, enc.Flush(), "Error should be nil")
+	stats := enc.Stats()
+	assert.True(t, w.writes > 1, "buffer should have been flushed")
+	assert.Equal(t, 10, stats.Counts[KindObject], "embedded objects should be counted")
+	assert.Equal(t, w.Len(), stats.Bytes[KindArray], "flushed bytes should be counted")
+}
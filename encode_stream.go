@@ -0,0 +1,93 @@
+package gojay
+
+// streamFlushSize is the number of buffered bytes from which EncodeObject, EncodeArray and Encode flush the buffer.
+const streamFlushSize = 4096
+
+// EncodeObject writes the JSON encoding of v to the Encoder's io.Writer.
+//
+// The buffer is flushed to the io.Writer as it fills while v is encoded, then once v is entirely encoded,
+// so that the memory used stays bounded whatever the size of v.
+// Successive calls write the values one after the other, without separator.
+// If the Encoder was not created with NewEncoderWriter, a NoWriterError is returned.
+func (enc *Encoder) EncodeObject(v MarshalerObject) error {
+	return enc.stream(func() error {
+		enc.writeObject(v)
+		return enc.err
+	})
+}
+
+// EncodeArray writes the JSON encoding of v to the Encoder's io.Writer.
+//
+// The buffer is flushed to the io.Writer as it fills while v is encoded, then once v is entirely encoded,
+// so that the memory used stays bounded whatever the size of v.
+// Successive calls write the values one after the other, without separator.
+// If the Encoder was not created with NewEncoderWriter, a NoWriterError is returned.
+func (enc *Encoder) EncodeArray(v MarshalerArray) error {
+	return enc.stream(func() error {
+		enc.writeArray(v)
+		return enc.err
+	})
+}
+
+// Encode writes the JSON encoding of v to the Encoder's io.Writer, it accepts the same types as Marshal.
+//
+// If v implements MarshalerObject or MarshalerArray, it is written as EncodeObject or EncodeArray do.
+// If the Encoder was not created with NewEncoderWriter, a NoWriterError is returned.
+func (enc *Encoder) Encode(v interface{}) error {
+	switch vt := v.(type) {
+	case MarshalerObject:
+		return enc.EncodeObject(vt)
+	case MarshalerArray:
+		return enc.EncodeArray(vt)
+	}
+	return enc.stream(func() error {
+		return enc.encodeValue(v)
+	})
+}
+
+// stream calls encode to write a top level value, flushing the buffer as it fills, then flushes what remains.
+func (enc *Encoder) stream(encode func() error) error {
+	if enc.w == nil {
+		return NoWriterError("No writer given to Encoder")
+	}
+	enc.hasValue = false
+	enc.streaming = true
+	enc.streamErr = nil
+	enc.err = nil
+	mark, written := len(enc.buf), enc.written
+	err := encode()
+	enc.streaming = false
+	if err == nil {
+		err = enc.err
+	}
+	if err == nil {
+		err = enc.streamErr
+	}
+	if err != nil {
+		// the part of the value not flushed yet is discarded, so that it is not written with the next value
+		if enc.written == written {
+			enc.buf = enc.buf[:mark]
+		} else {
+			enc.buf = enc.buf[:0]
+		}
+		return err
+	}
+	if enc.trailingNewline {
+		enc.writeByte('\n')
+	}
+	return enc.Flush()
+}
+
+// SetTrailingNewline sets whether EncodeObject, EncodeArray and Encode terminate each value they write with a new line,
+// as encoding/json's Encoder does, so that successive values are written one per line.
+// It is off by default, values are then written one after the other without separator.
+func (enc *Encoder) SetTrailingNewline(on bool) {
+	enc.trailingNewline = on
+}
+
+// streamFlush flushes the buffer if it holds streamFlushSize bytes and no truncation point is pinned in it.
+func (enc *Encoder) streamFlush() {
+	if enc.pinned == 0 && len(enc.buf) >= streamFlushSize && enc.streamErr == nil {
+		enc.streamErr = enc.Flush()
+	}
+}
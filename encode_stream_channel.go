@@ -0,0 +1,265 @@
+package gojay
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// StreamFraming is how a StreamEncoder delimits the values it writes.
+type StreamFraming int
+
+// Framings of the values written by a StreamEncoder.
+const (
+	// FramingNewline writes each value followed by a new line, as line delimited JSON
+	FramingNewline StreamFraming = iota
+	// FramingArray writes the values separated by commas inside a JSON array, so that the whole stream is one JSON value
+	FramingArray
+	// FramingLengthPrefixed writes each value prefixed by its length in bytes, as a 4 bytes big endian unsigned integer
+	FramingLengthPrefixed
+)
+
+// A StreamEncoder encodes the values received from a channel to an output stream, as line delimited JSON
+// unless another framing is set, with several goroutines.
+//
+// The values encoded wait in a queue to be written to the io.Writer by a single goroutine.
+// When the io.Writer is slow and the queue is full, the encoding goroutines block until there is room,
+// or drop the values they encoded if DropWhenSlow is set, so that the memory used stays bounded.
+type StreamEncoder struct {
+	w               io.Writer
+	nConsumer       int
+	framing         StreamFraming
+	maxInFlight     int
+	maxMessageBytes int
+	dropWhenSlow    bool
+	dropped         int64
+}
+
+// NewEncoder returns a StreamEncoder writing to w, with a single consumer goroutine, see NConsumer.
+func (s stream) NewEncoder(w io.Writer) *StreamEncoder {
+	return &StreamEncoder{w: w, nConsumer: 1}
+}
+
+// NConsumer sets the number of goroutines encoding the values received, 1 if n is lower than 1.
+// With more than one goroutine, the values are written in the order their encoding ends,
+// which may differ from the order they were received.
+func (s *StreamEncoder) NConsumer(n int) *StreamEncoder {
+	if n < 1 {
+		n = 1
+	}
+	s.nConsumer = n
+	return s
+}
+
+// Framing sets how the values are delimited in the output, FramingNewline by default.
+func (s *StreamEncoder) Framing(f StreamFraming) *StreamEncoder {
+	s.framing = f
+	return s
+}
+
+// MaxInFlight sets the number of encoded values which can wait to be written to the io.Writer,
+// the number of consumers if n is lower than 1, which is the default.
+func (s *StreamEncoder) MaxInFlight(n int) *StreamEncoder {
+	s.maxInFlight = n
+	return s
+}
+
+// MaxMessageBytes sets the maximum number of bytes of an encoded value, framing excluded, 0 for no limit.
+// A value exceeding it stops the stream with a LimitExceededError.
+// Buffers are reused from one value to the next, the limit also bounds the memory they retain.
+func (s *StreamEncoder) MaxMessageBytes(n int) *StreamEncoder {
+	s.maxMessageBytes = n
+	return s
+}
+
+// DropWhenSlow sets whether a value encoded while the queue of values waiting to be written is full is dropped,
+// instead of waiting for room. The number of values dropped is returned by Dropped.
+func (s *StreamEncoder) DropWhenSlow(drop bool) *StreamEncoder {
+	s.dropWhenSlow = drop
+	return s
+}
+
+// Dropped returns the number of values dropped by the last call to EncodeStream, see DropWhenSlow.
+func (s *StreamEncoder) Dropped() int {
+	return int(atomic.LoadInt64(&s.dropped))
+}
+
+// EncodeStream encodes the values received from c, each one as a compact JSON object framed as set by Framing,
+// until c is closed or ctx is done.
+//
+// Each goroutine borrows an Encoder from the pool, the buffers the values are encoded to are reused
+// from one value to the next. A value is written to the io.Writer with its framing in a single call to Write,
+// so values never interleave. With FramingArray, the opening bracket is written first and the closing one
+// once c is closed or ctx is done, it is not written after an error.
+//
+// EncodeStream returns once every goroutine has stopped: nil once c is closed and drained, the error of ctx
+// if it is done first, or the first error returned by the io.Writer, set by a MarshalObject method with SetError,
+// or caused by a value exceeding MaxMessageBytes, which stops the other goroutines.
+func (s *StreamEncoder) EncodeStream(ctx context.Context, c <-chan MarshalerStream) error {
+	if s.w == nil {
+		return NoWriterError("No writer given to encode stream")
+	}
+	atomic.StoreInt64(&s.dropped, 0)
+	if s.framing == FramingArray {
+		if _, err := s.w.Write([]byte{'['}); err != nil {
+			return err
+		}
+	}
+	maxInFlight := s.maxInFlight
+	if maxInFlight < 1 {
+		maxInFlight = s.nConsumer
+	}
+	workCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var once sync.Once
+	var firstErr error
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	frames := make(chan []byte, maxInFlight)
+	// free holds the buffers written, to be reused, there are never more than the frames queued and being encoded
+	free := make(chan []byte, maxInFlight+s.nConsumer+1)
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		s.writeFrames(frames, free, fail)
+	}()
+	var wg sync.WaitGroup
+	for i := 0; i < s.nConsumer; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			enc := BorrowEncoder()
+			defer enc.Release()
+			for {
+				select {
+				case <-workCtx.Done():
+					return
+				case v, ok := <-c:
+					if !ok {
+						return
+					}
+					select {
+					case enc.buf = <-free:
+					default:
+					}
+					frame, err := s.encodeFrame(enc, v)
+					if err != nil {
+						fail(err)
+						return
+					}
+					if !s.queue(workCtx, frames, free, frame) {
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(frames)
+	<-writerDone
+	if firstErr != nil {
+		return firstErr
+	}
+	if s.framing == FramingArray {
+		if _, err := s.w.Write([]byte{']'}); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// encodeFrame encodes v to enc, reset beforehand, with room kept for its framing, and returns its buffer,
+// which enc gives up.
+func (s *StreamEncoder) encodeFrame(enc *Encoder, v MarshalerStream) ([]byte, error) {
+	enc.Reset()
+	// room is kept at the start of the buffer for the separator or the length prefix
+	prefix := s.framePrefix()
+	enc.grow(prefix)
+	enc.buf = enc.buf[:prefix]
+	enc.writeObject(v)
+	if enc.err != nil {
+		return nil, enc.err
+	}
+	if s.maxMessageBytes > 0 && len(enc.buf)-prefix > s.maxMessageBytes {
+		return nil, LimitExceededError(
+			fmt.Sprintf("Cannot encode stream, value of %d bytes exceeds the limit of %d bytes", len(enc.buf)-prefix, s.maxMessageBytes),
+		)
+	}
+	switch s.framing {
+	case FramingArray:
+		enc.buf[0] = ','
+	case FramingLengthPrefixed:
+		binary.BigEndian.PutUint32(enc.buf, uint32(len(enc.buf)-prefix))
+	default:
+		enc.writeByte('\n')
+	}
+	frame := enc.buf
+	enc.buf = nil
+	return frame, nil
+}
+
+// framePrefix returns the number of bytes the framing writes before a value.
+func (s *StreamEncoder) framePrefix() int {
+	switch s.framing {
+	case FramingArray:
+		return 1
+	case FramingLengthPrefixed:
+		return 4
+	}
+	return 0
+}
+
+// queue queues frame to be written, waiting for room unless DropWhenSlow is set.
+// It returns false if ctx is done meanwhile.
+func (s *StreamEncoder) queue(ctx context.Context, frames, free chan []byte, frame []byte) bool {
+	if s.dropWhenSlow {
+		select {
+		case frames <- frame:
+		default:
+			atomic.AddInt64(&s.dropped, 1)
+			releaseFrame(free, frame)
+		}
+		return true
+	}
+	select {
+	case frames <- frame:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// writeFrames writes the frames queued until frames is closed, giving their buffers back to free.
+// Once writing fails, the following frames are discarded.
+func (s *StreamEncoder) writeFrames(frames <-chan []byte, free chan []byte, fail func(error)) {
+	written, failed := false, false
+	for frame := range frames {
+		if !failed {
+			b := frame
+			if s.framing == FramingArray && !written {
+				b = b[1:]
+			}
+			written = true
+			if _, err := s.w.Write(b); err != nil {
+				failed = true
+				fail(err)
+			}
+		}
+		releaseFrame(free, frame)
+	}
+}
+
+// releaseFrame gives buf back to free to be reused, if there is room.
+func releaseFrame(free chan []byte, buf []byte) {
+	select {
+	case free <- buf[:0]:
+	default:
+	}
+}
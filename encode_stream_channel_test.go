@@ -0,0 +1,126 @@
+package gojay
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamEncoder(t *testing.T) {
+	builder := &strings.Builder{}
+	c := make(chan MarshalerStream)
+	go func() {
+		for i := 0; i < 100; i++ {
+			c <- &testLineEvent{i, "e"}
+		}
+		close(c)
+	}()
+	err := Stream.NewEncoder(builder).NConsumer(4).EncodeStream(context.Background(), c)
+	assert.Nil(t, err, "Error should be nil")
+	lines := strings.Split(strings.TrimSuffix(builder.String(), "\n"), "\n")
+	assert.Len(t, lines, 100, "every value should be written on its own line")
+	expected := make([]string, 100)
+	for i := range expected {
+		expected[i] = `{"id":` + strconv.Itoa(i) + `,"name":"e"}`
+	}
+	sort.Strings(lines)
+	sort.Strings(expected)
+	assert.Equal(t, expected, lines, "Result of marshalling is different as the one expected")
+}
+
+func TestStreamEncoderErrors(t *testing.T) {
+	c := make(chan MarshalerStream, 2)
+	c <- &testLineEvent{1, "a"}
+	c <- EncodeObjectFunc(func(enc *Encoder) {
+		enc.SetError(errTestMarshal)
+	})
+	err := Stream.NewEncoder(&strings.Builder{}).NConsumer(0).EncodeStream(context.Background(), c)
+	assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = Stream.NewEncoder(&strings.Builder{}).EncodeStream(ctx, make(chan MarshalerStream))
+	assert.Equal(t, context.Canceled, err, "err should be the one of the context")
+
+	err = Stream.NewEncoder(nil).EncodeStream(context.Background(), c)
+	assert.IsType(t, NoWriterError(""), err, "err should be of type NoWriterError")
+
+	c = make(chan MarshalerStream, 1)
+	c <- &testLineEvent{1, "a"}
+	close(c)
+	err = Stream.NewEncoder(testErrWriter{errTestMarshal}).EncodeStream(context.Background(), c)
+	assert.Equal(t, errTestMarshal, err, "err should be the one of the io.Writer")
+}
+
+func TestStreamEncoderFraming(t *testing.T) {
+	send := func(n int) chan MarshalerStream {
+		c := make(chan MarshalerStream, n)
+		for i := 0; i < n; i++ {
+			c <- &testLineEvent{i, "e"}
+		}
+		close(c)
+		return c
+	}
+	builder := &strings.Builder{}
+	err := Stream.NewEncoder(builder).Framing(FramingArray).EncodeStream(context.Background(), send(3))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `[{"id":0,"name":"e"},{"id":1,"name":"e"},{"id":2,"name":"e"}]`, builder.String(), "values should be written in an array")
+
+	builder.Reset()
+	err = Stream.NewEncoder(builder).Framing(FramingArray).NConsumer(3).EncodeStream(context.Background(), send(0))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `[]`, builder.String(), "empty stream should be an empty array")
+
+	builder.Reset()
+	err = Stream.NewEncoder(builder).Framing(FramingLengthPrefixed).EncodeStream(context.Background(), send(2))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "\x00\x00\x00\x13{\"id\":0,\"name\":\"e\"}\x00\x00\x00\x13{\"id\":1,\"name\":\"e\"}", builder.String(), "values should be prefixed by their length")
+}
+
+// testSlowWriter blocks every Write until a value is received from unblock
+type testSlowWriter struct {
+	unblock chan struct{}
+	strings.Builder
+}
+
+func (w *testSlowWriter) Write(b []byte) (int, error) {
+	<-w.unblock
+	return w.Builder.Write(b)
+}
+
+func TestStreamEncoderBackpressure(t *testing.T) {
+	w := &testSlowWriter{unblock: make(chan struct{})}
+	c := make(chan MarshalerStream)
+	s := Stream.NewEncoder(w).MaxInFlight(1).DropWhenSlow(true)
+	done := make(chan error)
+	go func() {
+		done <- s.EncodeStream(context.Background(), c)
+	}()
+	// the first value is being written, the second one waits in the queue, the others are dropped
+	for i := 0; i < 5; i++ {
+		c <- &testLineEvent{i, "e"}
+	}
+	close(c)
+	close(w.unblock)
+	err := <-done
+	assert.Nil(t, err, "Error should be nil")
+	lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+	assert.Equal(t, 5, len(lines)+s.Dropped(), "values should be either written or dropped")
+	assert.True(t, s.Dropped() > 0, "values should be dropped while the writer is slow")
+	assert.Equal(t, `{"id":0,"name":"e"}`, lines[0], "first value should be written")
+}
+
+func TestStreamEncoderMaxMessageBytes(t *testing.T) {
+	c := make(chan MarshalerStream, 2)
+	c <- &testLineEvent{1, "a"}
+	c <- &testLineEvent{2, strings.Repeat("a", 100)}
+	close(c)
+	builder := &strings.Builder{}
+	err := Stream.NewEncoder(builder).MaxMessageBytes(50).EncodeStream(context.Background(), c)
+	assert.IsType(t, LimitExceededError(""), err, "err should be of type LimitExceededError")
+	assert.Equal(t, "{\"id\":1,\"name\":\"a\"}\n", builder.String(), "values within the limit should be written")
+}
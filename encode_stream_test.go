@@ -0,0 +1,126 @@
+package gojay
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStreamSlice []*testObject
+
+func (t testStreamSlice) MarshalArray(enc *Encoder) {
+	for _, e := range t {
+		enc.AddObject(e)
+	}
+}
+
+func TestEncoderEncodeArray(t *testing.T) {
+	v := make(testStreamSlice, 1000)
+	for i := range v {
+		v[i] = &testObject{testStr: "string " + strconv.Itoa(i), testInt: i}
+	}
+	expected, err := MarshalArray(v)
+	assert.Nil(t, err, "Error should be nil")
+
+	w := &testFlushWriter{}
+	enc := NewEncoderWriter(w)
+	defer enc.addToPool()
+	err = enc.EncodeArray(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, string(expected), w.String(), "Result of encoding is different as the one expected")
+	assert.True(t, w.writes > 10, "output should have been written in several flushes")
+	assert.True(t, cap(enc.Bytes()) < len(expected)/4, "buffer should not hold the whole output")
+	assert.Equal(t, 0, len(enc.Bytes()), "buffer should be empty once encoded")
+}
+
+func TestEncoderEncodeSeveralValues(t *testing.T) {
+	w := &testFlushWriter{}
+	enc := NewEncoderWriter(w)
+	defer enc.addToPool()
+	err := enc.EncodeObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddIntKey("a", 1)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	for _, v := range []interface{}{"str", 2, true, EncodeArrayFunc(func(enc *Encoder) { enc.AddInt(3) })} {
+		err = enc.Encode(v)
+		assert.Nil(t, err, "Error should be nil")
+	}
+	assert.Equal(t, `{"a":1}"str"2true[3]`, w.String(), "Result of encoding is different as the one expected")
+
+	err = enc.Encode(make(chan int))
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+}
+
+func TestEncoderSetTrailingNewline(t *testing.T) {
+	w := &testFlushWriter{}
+	enc := NewEncoderWriter(w)
+	enc.SetTrailingNewline(true)
+	err := enc.EncodeObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddIntKey("a", 1)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	err = enc.Encode("str")
+	assert.Nil(t, err, "Error should be nil")
+	err = enc.Encode(make(chan int))
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	err = enc.EncodeArray(EncodeArrayFunc(func(enc *Encoder) { enc.AddInt(3) }))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "{\"a\":1}\n\"str\"\n[3]\n", w.String(), "each value should be followed by a new line")
+
+	enc.Reset()
+	err = enc.Encode(1)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "{\"a\":1}\n\"str\"\n[3]\n1\n", w.String(), "setting should be kept by Reset")
+
+	enc.addToPool()
+	assert.False(t, enc.trailingNewline, "setting should be cleared when the encoder is pooled")
+	pooled := NewEncoderWriter(w)
+	defer pooled.addToPool()
+	err = pooled.Encode(2)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "{\"a\":1}\n\"str\"\n[3]\n1\n2", w.String(), "setting should not leak to a pooled encoder")
+}
+
+func TestEncoderEncodeBudgeted(t *testing.T) {
+	long := strings.Repeat("a", streamFlushSize)
+	v := EncodeObjectFunc(func(enc *Encoder) {
+		i := 0
+		enc.AddArrayKeyBudgeted("arr", streamFlushSize*3, func(enc *Encoder) bool {
+			if i == 10 {
+				return false
+			}
+			i++
+			return enc.AddString(long) == nil
+		})
+	})
+	expected, _ := MarshalObject(v)
+	w := &testFlushWriter{}
+	enc := NewEncoderWriter(w)
+	defer enc.addToPool()
+	err := enc.EncodeObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, string(expected), w.String(), "budgeted array should be truncated as when not streamed")
+	assert.True(t, strings.HasSuffix(w.String(), `"...truncated 8 more"]}`), "array should be truncated")
+}
+
+func TestEncoderEncodeErrors(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	err := enc.EncodeObject(&testObject{})
+	assert.IsType(t, NoWriterError(""), err, "err should be of type NoWriterError")
+	err = enc.Encode(1)
+	assert.IsType(t, NoWriterError(""), err, "err should be of type NoWriterError")
+
+	testErr := errors.New("write error")
+	enc = NewEncoderWriter(testErrWriter{testErr})
+	defer enc.addToPool()
+	v := make(testStreamSlice, 1000)
+	for i := range v {
+		v[i] = &testObject{testInt: i}
+	}
+	err = enc.EncodeArray(v)
+	assert.Equal(t, testErr, err, "err should be the one returned by the writer")
+}
@@ -1,38 +1,263 @@
 package gojay
 
-// encodeString encodes a string to 
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const hexChars = "0123456789abcdef"
+
+// encodeString encodes a string to
 func (enc *Encoder) encodeString(s string) ([]byte, error) {
 	enc.writeByte('"')
-	enc.writeString(s)
+	enc.writeStringEscape(s)
 	enc.writeByte('"')
 	return enc.buf, nil
 }
 
 // AddString adds a string to be encoded, must be used inside a slice or array encoding (does not encode a key)
 func (enc *Encoder) AddString(value string) error {
-	r, ok := enc.getPreviousRune()
-	if ok && r != '[' {
-		enc.writeByte(',')
-	}
+	start := enc.offset()
+	enc.writeSep()
 	enc.writeByte('"')
-	enc.writeString(value)
+	enc.writeStringValue(value)
 	enc.writeByte('"')
 
+	enc.record(KindString, start)
 	return nil
 }
 
 // AddStringKey adds a string to be encoded, must be used inside an object as it will encode a key
 func (enc *Encoder) AddStringKey(key, value string) error {
-	// grow to avoid allocs (length of key/value + quotes)
-	r, ok := enc.getPreviousRune()
-	if ok && r != '{' && r != '[' {
-		enc.writeByte(',')
+	if enc.skipKey(key) {
+		return nil
 	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.AddStringKey(key, value) })
+	}
+	start := enc.offset()
+	enc.writeSep()
 	enc.writeByte('"')
-	enc.writeString(key)
-	enc.write(objKeyStr)
-	enc.writeString(value)
+	enc.writeKey(key)
+	enc.writeObjKey(objKeyStr)
+	enc.writeStringValue(value)
 	enc.writeByte('"')
 
+	enc.record(KindString, start)
 	return nil
-}
\ No newline at end of file
+}
+
+// AddStringKeyOmitEmpty adds a string to be encoded, must be used inside an object as it will encode a key
+// If value is empty, nothing is written, not even the key.
+func (enc *Encoder) AddStringKeyOmitEmpty(key, value string) error {
+	if value == "" {
+		return nil
+	}
+	return enc.AddStringKey(key, value)
+}
+
+// AddStringURLEncoded adds a string percent-encoded as by url.QueryEscape, must be used inside a slice or array encoding (does not encode a key)
+//
+// The value goes through two layers of encoding: it is first percent-encoded, then written as a JSON string.
+// As percent-encoding only outputs unreserved ASCII characters and '%' escapes, the JSON layer never needs to escape it.
+// For example "a b&c" is encoded as "a+b%26c".
+func (enc *Encoder) AddStringURLEncoded(value string) error {
+	return enc.AddString(url.QueryEscape(value))
+}
+
+// AddStringKeyURLEncoded adds a string percent-encoded as by url.QueryEscape, must be used inside an object as it will encode a key
+//
+// The value goes through two layers of encoding: it is first percent-encoded, then written as a JSON string.
+// As percent-encoding only outputs unreserved ASCII characters and '%' escapes, the JSON layer never needs to escape it.
+// For example "a b&c" is encoded as "a+b%26c".
+func (enc *Encoder) AddStringKeyURLEncoded(key, value string) error {
+	return enc.AddStringKey(key, url.QueryEscape(value))
+}
+
+// AddStringer adds the String() of s to be encoded as a string, must be used inside a slice or array encoding (does not encode a key)
+// If s is nil or a typed nil, null is written and String() is not called.
+// String() is called once and its result escaped straight into the buffer, so enums implementing fmt.Stringer
+// need no Marshaler of their own, see EnumMapping to decode them.
+func (enc *Encoder) AddStringer(s fmt.Stringer) error {
+	if isNilValue(s) {
+		return enc.AddNull()
+	}
+	return enc.AddString(s.String())
+}
+
+// AddStringerKey adds the String() of s to be encoded as a string, must be used inside an object as it will encode a key
+// If s is nil or a typed nil, null is written and String() is not called.
+// String() is called once and its result escaped straight into the buffer, so enums implementing fmt.Stringer
+// need no Marshaler of their own, see EnumMapping to decode them.
+func (enc *Encoder) AddStringerKey(key string, s fmt.Stringer) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if isNilValue(s) {
+		return enc.AddNullKey(key)
+	}
+	return enc.AddStringKey(key, s.String())
+}
+
+// isNilValue reports whether i is nil or an interface holding a nil value,
+// on which calling a method such as String() would likely panic.
+func isNilValue(i interface{}) bool {
+	if i == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(i); v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Interface, reflect.Chan:
+		return v.IsNil()
+	}
+	return false
+}
+
+// AddStringTransformed adds a string to be encoded, must be used inside a slice or array encoding (does not encode a key)
+// value is passed through transform before being escaped, transform is applied in place of the one set with SetStringValueTransform.
+func (enc *Encoder) AddStringTransformed(value string, transform func(string) string) error {
+	global := enc.strTransform
+	enc.strTransform = transform
+	err := enc.AddString(value)
+	enc.strTransform = global
+	return err
+}
+
+// AddStringKeyTransformed adds a string to be encoded, must be used inside an object as it will encode a key
+// value is passed through transform before being escaped, transform is applied in place of the one set with SetStringValueTransform.
+func (enc *Encoder) AddStringKeyTransformed(key, value string, transform func(string) string) error {
+	global := enc.strTransform
+	enc.strTransform = transform
+	err := enc.AddStringKey(key, value)
+	enc.strTransform = global
+	return err
+}
+
+// SetStringValueTransform sets a function applied to every string value before it is escaped,
+// such as strings.TrimSpace, so that values are normalized in a single place.
+//
+// It applies to the values added with AddString, AddStringKey and the methods built on them, keys are not transformed.
+func (enc *Encoder) SetStringValueTransform(transform func(string) string) {
+	enc.strTransform = transform
+}
+
+// SetEscapeForwardSlash sets whether '/' must be escaped as '\/' in string values.
+// By default, '/' is written as is.
+func (enc *Encoder) SetEscapeForwardSlash(escape bool) {
+	t := *enc.getEscapeTable()
+	t['/'] = escape
+	enc.SetEscapeTable(t)
+}
+
+// writeStringValue writes the escaped string value s,
+// using the string value cache if one is set on the Encoder.
+func (enc *Encoder) writeStringValue(s string) {
+	if enc.strTransform != nil {
+		s = enc.strTransform(s)
+	}
+	if enc.strCache == nil || len(s) > maxCachedStringLen {
+		enc.writeStringEscape(s)
+		return
+	}
+	if b, ok := enc.strCache.get(s); ok {
+		enc.write(b)
+		return
+	}
+	start := len(enc.buf)
+	enc.writeStringEscape(s)
+	enc.strCache.add(s, enc.buf[start:])
+}
+
+// writeStringEscape writes s escaping the bytes flagged in the Encoder's escape table.
+//
+// With the default table, s is scanned 8 bytes at a time, see wordNeedsEscape, so that the spans
+// needing no escape are skipped without looking at each byte, then written with a single copy.
+func (enc *Encoder) writeStringEscape(s string) {
+	table := enc.getEscapeTable()
+	words := table == &defaultEscapeTable
+	start := 0
+	// bytes before next are checked one by one, they belong to a word needing escape
+	next := 0
+	for i := 0; i < len(s); i++ {
+		if words && i >= next {
+			for i+8 <= len(s) && !wordNeedsEscape(loadWord(s, i)) {
+				i += 8
+			}
+			if i >= len(s) {
+				break
+			}
+			next = i + 8
+		}
+		c := s[i]
+		if !table[c] {
+			continue
+		}
+		enc.writeString(s[start:i])
+		switch c {
+		case '"', '\\', '/':
+			enc.writeByte('\\')
+			enc.writeByte(c)
+		case '\n':
+			enc.writeString(`\n`)
+		case '\r':
+			enc.writeString(`\r`)
+		case '\t':
+			enc.writeString(`\t`)
+		case '\b':
+			enc.writeString(`\b`)
+		case '\f':
+			enc.writeString(`\f`)
+		default:
+			if c < utf8.RuneSelf {
+				enc.writeUnicodeEscape(rune(c))
+				break
+			}
+			// escape the whole rune the byte starts
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r >= 0x10000 {
+				r1, r2 := utf16.EncodeRune(r)
+				enc.writeUnicodeEscape(r1)
+				enc.writeUnicodeEscape(r2)
+			} else {
+				enc.writeUnicodeEscape(r)
+			}
+			i += size - 1
+		}
+		start = i + 1
+	}
+	enc.writeString(s[start:])
+}
+
+const (
+	wordOnes  = 0x0101010101010101
+	wordHighs = 0x8080808080808080
+)
+
+// loadWord returns the 8 bytes of s from i as a little endian uint64.
+func loadWord(s string, i int) uint64 {
+	s = s[i : i+8]
+	return uint64(s[0]) | uint64(s[1])<<8 | uint64(s[2])<<16 | uint64(s[3])<<24 |
+		uint64(s[4])<<32 | uint64(s[5])<<40 | uint64(s[6])<<48 | uint64(s[7])<<56
+}
+
+// wordNeedsEscape reports whether one of the 8 bytes of w is flagged in the default escape table:
+// a control character, '"' or '\'.
+//
+// (x - ones*n) & ^x & highs is non zero if and only if a byte of x is lower than n, for n up to 0x80,
+// a byte equal to b is found as a zero byte of x ^ ones*b.
+func wordNeedsEscape(w uint64) bool {
+	quote := w ^ (wordOnes * '"')
+	backslash := w ^ (wordOnes * '\\')
+	return ((w-wordOnes*0x20)&^w|(quote-wordOnes)&^quote|(backslash-wordOnes)&^backslash)&wordHighs != 0
+}
+
+// writeUnicodeEscape writes r, which must be lower than 0x10000, as a \uXXXX escape sequence.
+func (enc *Encoder) writeUnicodeEscape(r rune) {
+	enc.writeString(`\u`)
+	enc.writeByte(hexChars[r>>12&0xF])
+	enc.writeByte(hexChars[r>>8&0xF])
+	enc.writeByte(hexChars[r>>4&0xF])
+	enc.writeByte(hexChars[r&0xF])
+}
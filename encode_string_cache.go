@@ -0,0 +1,68 @@
+package gojay
+
+import "container/list"
+
+// maxCachedStringLen is the maximum length of a string value kept in the string value cache,
+// longer values are unlikely to be repeated and would make the cache memory unpredictable.
+const maxCachedStringLen = 128
+
+// SetStringValueCache sets a cache memoizing the escaped form of the last size distinct string values
+// added through AddString and AddStringKey. On a cache hit, the pre-escaped bytes are copied to the output.
+//
+// The cache is bounded, once it holds size values, the least recently used one is evicted.
+// A size lower than 1 disables the cache.
+func (enc *Encoder) SetStringValueCache(size int) {
+	if size < 1 {
+		enc.strCache = nil
+		return
+	}
+	enc.strCache = newStringCache(size)
+}
+
+// stringCache is a LRU cache of escaped string values
+type stringCache struct {
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type stringCacheEntry struct {
+	value   string
+	escaped []byte
+}
+
+func newStringCache(size int) *stringCache {
+	return &stringCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element, size),
+	}
+}
+
+// reset empties the cache, it must be called when the escaping rules of the Encoder change.
+func (c *stringCache) reset() {
+	c.ll.Init()
+	c.items = make(map[string]*list.Element, c.size)
+}
+
+func (c *stringCache) get(s string) ([]byte, bool) {
+	if e, ok := c.items[s]; ok {
+		c.ll.MoveToFront(e)
+		return e.Value.(*stringCacheEntry).escaped, true
+	}
+	return nil, false
+}
+
+func (c *stringCache) add(s string, escaped []byte) {
+	// copy value and escaped bytes as both may point to memory we don't own
+	entry := &stringCacheEntry{
+		value:   string(append([]byte(nil), s...)),
+		escaped: append([]byte(nil), escaped...),
+	}
+	c.items[entry.value] = c.ll.PushFront(entry)
+	if c.ll.Len() > c.size {
+		last := c.ll.Back()
+		c.ll.Remove(last)
+		delete(c.items, last.Value.(*stringCacheEntry).value)
+	}
+}
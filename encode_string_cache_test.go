@@ -0,0 +1,112 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStringCacheObj struct {
+	status   string
+	category string
+	label    string
+}
+
+func (t *testStringCacheObj) IsNil() bool {
+	return t == nil
+}
+
+func (t *testStringCacheObj) MarshalObject(enc *Encoder) {
+	enc.AddStringKey("status", t.status)
+	enc.AddStringKey("category", t.category)
+	enc.AddStringKey("label", t.label)
+}
+
+type testStringCacheSlice []*testStringCacheObj
+
+func (t testStringCacheSlice) MarshalArray(enc *Encoder) {
+	for _, e := range t {
+		enc.AddObject(e)
+	}
+}
+
+func TestEncoderStringValueCache(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetStringValueCache(2)
+	v := testStringCacheSlice{
+		{"ok", "books", "quote \" label"},
+		{"ok", "books", "quote \" label"},
+		{"ko", "music", "quote \" label"},
+	}
+	err := enc.AddArray(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`[{"status":"ok","category":"books","label":"quote \" label"},`+
+			`{"status":"ok","category":"books","label":"quote \" label"},`+
+			`{"status":"ko","category":"music","label":"quote \" label"}]`,
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+	assert.Equal(t, 2, enc.strCache.ll.Len(), "cache should not hold more than its size")
+	assert.Equal(t, 2, len(enc.strCache.items), "cache should not hold more than its size")
+}
+
+func TestEncoderStringValueCacheLRU(t *testing.T) {
+	c := newStringCache(2)
+	c.add("a", []byte("a"))
+	c.add("b", []byte("b"))
+	// a becomes the most recently used
+	_, ok := c.get("a")
+	assert.True(t, ok, "a should be in cache")
+	c.add("c", []byte("c"))
+	_, ok = c.get("b")
+	assert.Equal(t, false, ok, "b should have been evicted")
+	b, ok := c.get("a")
+	assert.True(t, ok, "a should be in cache")
+	assert.Equal(t, "a", string(b), "cached bytes should be equal to 'a'")
+	_, ok = c.get("c")
+	assert.True(t, ok, "c should be in cache")
+}
+
+func TestEncoderStringValueCacheDisable(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetStringValueCache(10)
+	assert.NotNil(t, enc.strCache, "cache should be set")
+	enc.SetStringValueCache(0)
+	assert.Nil(t, enc.strCache, "cache should be nil")
+}
+
+var benchStringCachePayload = func() testStringCacheSlice {
+	statuses := []string{"active", "inactive", "pending"}
+	categories := []string{"books", "music", "movies", "games"}
+	s := make(testStringCacheSlice, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		s = append(s, &testStringCacheObj{
+			statuses[i%len(statuses)],
+			categories[i%len(categories)],
+			"a \"quoted\" label\twith\tescapes",
+		})
+	}
+	return s
+}()
+
+func BenchmarkEncoderStringValueNoCache(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		enc := NewEncoder()
+		enc.AddArray(benchStringCachePayload)
+		enc.addToPool()
+	}
+}
+
+func BenchmarkEncoderStringValueCache(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		enc := NewEncoder()
+		enc.SetStringValueCache(16)
+		enc.AddArray(benchStringCachePayload)
+		enc.addToPool()
+	}
+}
@@ -1,6 +1,7 @@
 package gojay
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,3 +26,194 @@ func TestEncoderStringUTF8(t *testing.T) {
 		string(r),
 		"Result of marshalling is different as the one expected")
 }
+
+func TestEncoderStringEscape(t *testing.T) {
+	r, err := Marshal("quote \" backslash \\ line\nreturn\r tab\t control \x01")
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`"quote \" backslash \\ line\nreturn\r tab\t control \u0001"`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+type testURLEncodedObject struct {
+	query string
+	parts []string
+}
+
+func (t *testURLEncodedObject) IsNil() bool {
+	return t == nil
+}
+
+func (t *testURLEncodedObject) MarshalObject(enc *Encoder) {
+	enc.AddStringKeyURLEncoded("query", t.query)
+	enc.AddArrayKey("parts", testURLEncodedSlice(t.parts))
+}
+
+type testURLEncodedSlice []string
+
+func (t testURLEncodedSlice) MarshalArray(enc *Encoder) {
+	for _, s := range t {
+		enc.AddStringURLEncoded(s)
+	}
+}
+
+func TestEncoderStringURLEncoded(t *testing.T) {
+	r, err := MarshalObject(&testURLEncodedObject{
+		query: `q=a b&c="d"/é`,
+		parts: []string{"a b", "c&d"},
+	})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"query":"q%3Da+b%26c%3D%22d%22%2F%C3%A9","parts":["a+b","c%26d"]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderStringEscapeForwardSlash(t *testing.T) {
+	testCases := []struct {
+		name     string
+		escape   bool
+		expected string
+	}{
+		{
+			name:     "default-no-escape",
+			expected: `{"url":"https://example.com/a/b","html":"</script>"}`,
+		},
+		{
+			name:     "escape",
+			escape:   true,
+			expected: `{"url":"https:\/\/example.com\/a\/b","html":"<\/script>"}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enc := NewEncoder()
+			defer enc.addToPool()
+			enc.SetStringValueCache(8)
+			// fill the cache with the default escaping first
+			enc.writeStringValue("</script>")
+			enc.buf = enc.buf[:0]
+			if testCase.escape {
+				enc.SetEscapeForwardSlash(true)
+			}
+			err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+				enc.AddStringKey("url", "https://example.com/a/b")
+				enc.AddStringKey("html", "</script>")
+			}))
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+		})
+	}
+}
+
+type testStringerEnum int
+
+func (e testStringerEnum) String() string {
+	switch e {
+	case 1:
+		return "active"
+	}
+	return `unknown "enum"`
+}
+
+type testStringerPtr struct {
+	id string
+}
+
+func (p *testStringerPtr) String() string {
+	return p.id
+}
+
+func TestEncoderStringer(t *testing.T) {
+	var nilPtr *testStringerPtr
+	r, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringerKey("status", testStringerEnum(1))
+		enc.AddStringerKey("other", testStringerEnum(2))
+		enc.AddStringerKey("id", &testStringerPtr{"abc"})
+		enc.AddStringerKey("typedNil", nilPtr)
+		enc.AddStringerKey("nil", nil)
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddStringer(nilPtr)
+			enc.AddStringer(testStringerEnum(1))
+			enc.AddStringer(nil)
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"status":"active","other":"unknown \"enum\"","id":"abc","typedNil":null,"nil":null,"arr":[null,"active",null]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderStringTransform(t *testing.T) {
+	mask := func(s string) string {
+		if len(s) <= 8 {
+			return s
+		}
+		return s[:4] + strings.Repeat("*", len(s)-8) + s[len(s)-4:]
+	}
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetStringValueCache(8)
+	enc.SetStringValueTransform(strings.TrimSpace)
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey(" name ", "  John \"Jo\"  ")
+		enc.AddStringKeyTransformed("country", " fr", strings.ToUpper)
+		enc.AddStringKeyTransformed("card", "4111111111111111", mask)
+		enc.AddArrayKey("tags", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddString(" a ")
+			enc.AddString(" a ")
+			enc.AddStringTransformed(" a ", nil)
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{" name ":"John \"Jo\"","country":" FR","card":"4111********1111","tags":["a","a"," a "]}`,
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderStringEscapeWords(t *testing.T) {
+	withTable := NewEncoder()
+	defer withTable.addToPool()
+	// a copy of the default table has another address, the string is then scanned byte by byte
+	withTable.SetEscapeTable(DefaultEscapeTable())
+	enc := NewEncoder()
+	defer enc.addToPool()
+	base := []byte("abcdefghijklmnopqrstuvwxyz")
+	for c := 0; c < 256; c++ {
+		for i := range base {
+			b := append([]byte(nil), base...)
+			b[i] = byte(c)
+			s := string(b[:i+1]) + "é" + string(b[i:])
+			enc.buf = enc.buf[:0]
+			enc.writeStringEscape(s)
+			withTable.buf = withTable.buf[:0]
+			withTable.writeStringEscape(s)
+			if string(enc.buf) != string(withTable.buf) {
+				assert.Equal(t, string(withTable.buf), string(enc.buf), "words should be escaped as bytes are")
+				return
+			}
+		}
+	}
+	assert.True(t, wordNeedsEscape(loadWord("abcdefg\x1f", 0)), "control character should need escape")
+	assert.True(t, wordNeedsEscape(loadWord(`\bcdefgh`, 0)), "backslash should need escape")
+	assert.False(t, wordNeedsEscape(loadWord("ab <>/é!", 0)), "word should not need escape")
+}
+
+func BenchmarkEncoderStringEscape(b *testing.B) {
+	s := strings.Repeat("the quick brown fox jumps over the lazy dog, ", 20) + "\"end\"\n"
+	enc := NewEncoder()
+	defer enc.addToPool()
+	b.SetBytes(int64(len(s)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		enc.buf = enc.buf[:0]
+		enc.writeStringEscape(s)
+	}
+}
@@ -1 +1,205 @@
 package gojay
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testVersionedObject struct {
+	id       int
+	name     string
+	fullName string
+}
+
+func (t *testVersionedObject) IsNil() bool {
+	return t == nil
+}
+
+func (t *testVersionedObject) MarshalObject(enc *Encoder) {
+	enc.AddIntKey("id", t.id)
+	if enc.SchemaVersion() < 2 {
+		enc.AddStringKey("name", t.name)
+		return
+	}
+	enc.AddStringKey("fullName", t.fullName)
+}
+
+func TestEncoderSchemaVersion(t *testing.T) {
+	v := &testVersionedObject{1, "gojay", "gojay json"}
+	testCases := []struct {
+		name     string
+		version  int
+		expected string
+	}{
+		{
+			name:     "default-version",
+			expected: `{"id":1,"name":"gojay"}`,
+		},
+		{
+			name:     "version-1",
+			version:  1,
+			expected: `{"id":1,"name":"gojay"}`,
+		},
+		{
+			name:     "version-2",
+			version:  2,
+			expected: `{"id":1,"fullName":"gojay json"}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enc := NewEncoder()
+			defer enc.addToPool()
+			if testCase.version > 0 {
+				enc.SetSchemaVersion(testCase.version)
+			}
+			assert.Equal(t, testCase.version, enc.SchemaVersion(), "schema version should be the one set")
+			err := enc.AddObject(v)
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+		})
+	}
+}
+
+type testFlushingObject struct {
+	depth int
+}
+
+func (t *testFlushingObject) IsNil() bool {
+	return t == nil
+}
+
+func (t *testFlushingObject) MarshalObject(enc *Encoder) {
+	enc.AddIntKey("depth", t.depth)
+	enc.Flush()
+	if t.depth < 3 {
+		enc.AddObjectKey("child", &testFlushingObject{t.depth + 1})
+		enc.Flush()
+	}
+	enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+		enc.AddInt(1)
+		enc.Flush()
+		enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.Flush()
+			enc.AddStringKey("a", "b")
+		}))
+		enc.Flush()
+		enc.AddInt(2)
+	}))
+	enc.AddBoolKey("last", true)
+}
+
+func TestEncoderFlushNested(t *testing.T) {
+	w := &testFlushWriter{}
+	enc := NewEncoderWriter(w)
+	defer enc.addToPool()
+	err := enc.AddObject(&testFlushingObject{1})
+	assert.Nil(t, err, "Error should be nil")
+	err = enc.Flush()
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"depth":1,"child":{"depth":2,"child":{"depth":3,"arr":[1,{"a":"b"},2],"last":true},`+
+			`"arr":[1,{"a":"b"},2],"last":true},"arr":[1,{"a":"b"},2],"last":true}`,
+		w.String(),
+		"Result of marshalling is different as the one expected")
+	assert.True(t, w.writes > 10, "output should have been written in several flushes")
+	assert.Equal(t, 0, len(enc.Bytes()), "buffer should be empty after flush")
+	assert.Equal(t, 0, enc.depth, "depth should be back to 0")
+}
+
+func TestEncoderFlushNoWriter(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	err := enc.Flush()
+	assert.NotNil(t, err, "Error should not be nil")
+	assert.IsType(t, NoWriterError(""), err, "err should be of type NoWriterError")
+}
+
+type testFlushWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *testFlushWriter) Write(b []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(b)
+}
+
+func TestEncoderLifecycle(t *testing.T) {
+	enc := BorrowEncoder()
+	enc.SetEscapeHTML(true)
+	err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddStringKey("a", "<b>")
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	first := string(enc.Bytes())
+	assert.Equal(t, `{"a":"\u003cb\u003e"}`, first, "Result of marshalling is different as the one expected")
+
+	enc.Reset()
+	assert.Equal(t, "", string(enc.Bytes()), "buffer should be empty after Reset")
+	err = enc.AddArray(EncodeArrayFunc(func(enc *Encoder) {
+		enc.AddString("<i>")
+		enc.AddInt(1)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `["\u003ci\u003e",1]`, string(enc.Bytes()), "settings should be kept after Reset")
+
+	b := enc.Bytes()
+	enc.Release()
+	assert.Equal(t, `["\u003ci\u003e",1]`, string(b), "bytes should stay valid after Release")
+}
+
+func TestMarshalObjectSize(t *testing.T) {
+	v := &testVersionedObject{id: 1, name: "a"}
+	expected, err := MarshalObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	r, err := MarshalObjectSize(v, 4096)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, string(expected), string(r), "Result of marshalling is different as the one expected")
+	assert.True(t, cap(r) >= 4096, "buffer should start with the capacity of the hint")
+	r, err = MarshalObjectSize(v, -1)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, string(expected), string(r), "Result of marshalling is different as the one expected")
+
+	r, err = MarshalArraySize(EncodeArrayFunc(func(enc *Encoder) { enc.AddInt(1) }), 1024)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, "[1]", string(r), "Result of marshalling is different as the one expected")
+	assert.True(t, cap(r) >= 1024, "buffer should start with the capacity of the hint")
+}
+
+func TestEncoderGrow(t *testing.T) {
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.grow(100)
+	assert.Equal(t, 100, cap(enc.buf), "buffer should be grown to the size asked")
+	enc.buf = enc.buf[:100]
+	enc.grow(10)
+	assert.Equal(t, 200, cap(enc.buf), "buffer should be doubled")
+	enc.grow(1000)
+	assert.Equal(t, 1100, cap(enc.buf), "buffer should be grown to the size needed")
+	enc.buf = make([]byte, growLargeBuffer)
+	enc.grow(1)
+	assert.Equal(t, growLargeBuffer+growLargeBuffer/4, cap(enc.buf), "large buffers should be grown by a quarter")
+	assert.Equal(t, growLargeBuffer, len(enc.buf), "grow should keep the length of the buffer")
+}
+
+func TestMarshalObjectString(t *testing.T) {
+	v := &testVersionedObject{id: 1, name: "a"}
+	expected, err := MarshalObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	s, err := MarshalObjectString(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, string(expected), s, "Result of marshalling is different as the one expected")
+	bytesAllocs := testing.AllocsPerRun(10, func() { MarshalObject(v) })
+	stringAllocs := testing.AllocsPerRun(10, func() { MarshalObjectString(v) })
+	assert.Equal(t, bytesAllocs, stringAllocs, "string should not be copied")
+
+	s, err = MarshalObjectString(EncodeObjectFunc(func(enc *Encoder) {
+		enc.SetError(errTestMarshal)
+	}))
+	assert.Equal(t, errTestMarshal, err, "err should be the one set by SetError")
+	assert.Equal(t, "", s, "result should be empty")
+}
@@ -0,0 +1,38 @@
+package gojay
+
+import "encoding"
+
+// AddTextMarshaler adds the text of an encoding.TextMarshaler to be encoded as a string,
+// must be used inside a slice or array encoding (does not encode a key)
+// If v is nil or a typed nil, null is written. If MarshalText fails, nothing is written and its error is returned,
+// it is also set with SetError.
+func (enc *Encoder) AddTextMarshaler(v encoding.TextMarshaler) error {
+	if isNilValue(v) {
+		return enc.AddNull()
+	}
+	text, err := v.MarshalText()
+	if err != nil {
+		enc.SetError(err)
+		return err
+	}
+	return enc.AddString(string(text))
+}
+
+// AddTextMarshalerKey adds the text of an encoding.TextMarshaler to be encoded as a string,
+// must be used inside an object as it will encode a key
+// If v is nil or a typed nil, null is written. If MarshalText fails, nothing is written and its error is returned,
+// it is also set with SetError.
+func (enc *Encoder) AddTextMarshalerKey(key string, v encoding.TextMarshaler) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if isNilValue(v) {
+		return enc.AddNullKey(key)
+	}
+	text, err := v.MarshalText()
+	if err != nil {
+		enc.SetError(err)
+		return err
+	}
+	return enc.AddStringKey(key, string(text))
+}
@@ -0,0 +1,85 @@
+package gojay
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTextLevel int
+
+func (l testTextLevel) MarshalText() ([]byte, error) {
+	switch l {
+	case 1:
+		return []byte("debug"), nil
+	case 2:
+		return []byte(`"quoted"`), nil
+	}
+	return nil, errors.New("unknown level")
+}
+
+func TestEncoderTextMarshaler(t *testing.T) {
+	r, err := Marshal(net.IPv4(127, 0, 0, 1))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `"127.0.0.1"`, string(r), "Result of marshalling is different as the one expected")
+
+	var nilPoint *testTextPoint
+	r, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddTextMarshalerKey("level", testTextLevel(1))
+		enc.AddInterfaceKey("quoted", testTextLevel(2))
+		enc.AddTextMarshalerKey("nil", nilPoint)
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddTextMarshaler(testTextLevel(1))
+			enc.AddTextMarshaler(nil)
+			enc.AddInterface(net.IPv4(10, 0, 0, 1))
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"level":"debug","quoted":"\"quoted\"","nil":null,"arr":["debug",null,"10.0.0.1"]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+
+	// values and map keys encoded by reflection
+	r, err = Marshal(struct {
+		Level testTextLevel         `json:"level"`
+		Map   map[testTextLevel]int `json:"map"`
+	}{1, map[testTextLevel]int{1: 1}})
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"level":"debug","map":{"debug":1}}`, string(r), "Result of marshalling is different as the one expected")
+
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.writeByte('[')
+	err = enc.AddTextMarshaler(testTextLevel(3))
+	assert.Equal(t, "unknown level", err.Error(), "err should be the one returned by MarshalText")
+	assert.Equal(t, `[`, string(enc.Bytes()), "nothing should be written")
+	assert.Equal(t, "unknown level", enc.Err().Error(), "error should be set on the encoder")
+
+	_, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddTextMarshalerKey("level", testTextLevel(3))
+		enc.AddIntKey("ok", 1)
+	}))
+	assert.Equal(t, "unknown level", err.Error(), "err should be the one returned by MarshalText")
+}
+
+type testTextPoint struct {
+	x, y string
+}
+
+func (p *testTextPoint) UnmarshalText(text []byte) error {
+	parts := strings.Split(string(text), ",")
+	if len(parts) != 2 {
+		return errors.New("invalid point")
+	}
+	p.x, p.y = parts[0], parts[1]
+	return nil
+}
+
+func (p *testTextPoint) MarshalText() ([]byte, error) {
+	return []byte(p.x + "," + p.y), nil
+}
@@ -0,0 +1,223 @@
+package gojay
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// TimeFormat is the format time.Times are encoded to by AddTime and AddTimeKey.
+type TimeFormat int
+
+// Formats of time.Times.
+const (
+	// TimeString encodes times as a string formatted with a layout, such as "2006-01-02T15:04:05Z"
+	TimeString TimeFormat = iota
+	// TimeUnix encodes times as an integer number of seconds since the Unix epoch, such as 1136214245
+	TimeUnix
+	// TimeUnixMilli encodes times as an integer number of milliseconds since the Unix epoch, such as 1136214245000
+	TimeUnixMilli
+	// TimeUnixMicro encodes times as an integer number of microseconds since the Unix epoch, such as 1136214245000000
+	TimeUnixMicro
+)
+
+// AddTimeFloatSeconds adds a time to be encoded as the number of seconds elapsed since epoch, as a float,
+// must be used inside a slice or array encoding (does not encode a key)
+//
+// Times before epoch are encoded as negative numbers. If t is nil, nothing is written.
+// If t is further than a time.Duration can hold from epoch, about 292 years, nothing is written
+// and an InvalidTypeError is returned and set as the error of the encoding, see SetError.
+func (enc *Encoder) AddTimeFloatSeconds(t *time.Time, epoch time.Time) error {
+	if t == nil {
+		return nil
+	}
+	s, err := enc.secondsSince(*t, epoch)
+	if err != nil {
+		return err
+	}
+	return enc.AddFloat(s)
+}
+
+// AddTimeFloatSecondsKey adds a time to be encoded as the number of seconds elapsed since epoch, as a float,
+// must be used inside an object as it will encode a key
+//
+// Times before epoch are encoded as negative numbers. If t is nil, the key is not written.
+// If t is further than a time.Duration can hold from epoch, about 292 years, the key is not written
+// and an InvalidTypeError is returned and set as the error of the encoding, see SetError.
+func (enc *Encoder) AddTimeFloatSecondsKey(key string, t *time.Time, epoch time.Time) error {
+	if t == nil || enc.skipKey(key) {
+		return nil
+	}
+	s, err := enc.secondsSince(*t, epoch)
+	if err != nil {
+		return err
+	}
+	return enc.AddFloatKey(key, s)
+}
+
+// secondsSince returns the seconds elapsed from epoch to t, t.Sub(epoch) saturating when the difference
+// overflows a time.Duration, an error is then set on the Encoder instead.
+func (enc *Encoder) secondsSince(t, epoch time.Time) (float64, error) {
+	d := t.Sub(epoch)
+	if !epoch.Add(d).Equal(t) {
+		err := InvalidTypeError(
+			fmt.Sprintf(
+				"Cannot marshal time %s, it is out of the range of a time.Duration from epoch %s",
+				t.Format(time.RFC3339Nano),
+				epoch.Format(time.RFC3339Nano),
+			),
+		)
+		enc.SetError(err)
+		return 0, err
+	}
+	return d.Seconds(), nil
+}
+
+// SetTimeFormat sets the format of the times added with AddTime and AddTimeKey, TimeString if not set.
+// When it is not TimeString, the layout given to AddTime and AddTimeKey is ignored.
+func (enc *Encoder) SetTimeFormat(format TimeFormat) {
+	enc.timeFormat = format
+}
+
+// SetTimeLayout sets the default layout of the times added with AddTime and AddTimeKey, time.RFC3339Nano if not set.
+func (enc *Encoder) SetTimeLayout(layout string) {
+	enc.timeLayout = layout
+}
+
+// AddTime adds a time to be encoded as a string formatted with layout, must be used inside a slice or array encoding (does not encode a key)
+// If layout is empty, the layout set with SetTimeLayout is used. The time is formatted directly into the buffer, without allocating.
+// If a TimeFormat other than TimeString is set with SetTimeFormat, the time is encoded as a number in that format instead,
+// if the number overflows an int64 nothing is written and an InvalidTypeError is returned and set as the error of the encoding.
+func (enc *Encoder) AddTime(t time.Time, layout string) error {
+	if err := enc.checkTime(t, enc.timeFormat); err != nil {
+		return err
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.record(enc.writeTimeFormat(t, enc.timeFormat, layout), start)
+	return nil
+}
+
+// AddTimeKey adds a time to be encoded as a string formatted with layout, must be used inside an object as it will encode a key
+// If layout is empty, the layout set with SetTimeLayout is used. The time is formatted directly into the buffer, without allocating.
+// If a TimeFormat other than TimeString is set with SetTimeFormat, the time is encoded as a number in that format instead,
+// if the number overflows an int64 nothing is written and an InvalidTypeError is returned and set as the error of the encoding.
+func (enc *Encoder) AddTimeKey(key string, t time.Time, layout string) error {
+	return enc.addTimeKey(key, t, enc.timeFormat, layout)
+}
+
+// AddTimeUnix adds a time to be encoded as an integer number of seconds since the Unix epoch,
+// must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddTimeUnix(t time.Time) error {
+	start := enc.offset()
+	enc.writeSep()
+	enc.record(enc.writeTimeFormat(t, TimeUnix, ""), start)
+	return nil
+}
+
+// AddTimeUnixKey adds a time to be encoded as an integer number of seconds since the Unix epoch,
+// must be used inside an object as it will encode a key
+func (enc *Encoder) AddTimeUnixKey(key string, t time.Time) error {
+	return enc.addTimeKey(key, t, TimeUnix, "")
+}
+
+// AddTimeUnixMilli adds a time to be encoded as an integer number of milliseconds since the Unix epoch,
+// must be used inside a slice or array encoding (does not encode a key)
+func (enc *Encoder) AddTimeUnixMilli(t time.Time) error {
+	if err := enc.checkTime(t, TimeUnixMilli); err != nil {
+		return err
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.record(enc.writeTimeFormat(t, TimeUnixMilli, ""), start)
+	return nil
+}
+
+// AddTimeUnixMilliKey adds a time to be encoded as an integer number of milliseconds since the Unix epoch,
+// must be used inside an object as it will encode a key
+func (enc *Encoder) AddTimeUnixMilliKey(key string, t time.Time) error {
+	return enc.addTimeKey(key, t, TimeUnixMilli, "")
+}
+
+func (enc *Encoder) addTimeKey(key string, t time.Time, format TimeFormat, layout string) error {
+	if enc.skipKey(key) {
+		return nil
+	}
+	if enc.keyHooked(key) {
+		return enc.hookField(key, func() error { return enc.addTimeKey(key, t, format, layout) })
+	}
+	if err := enc.checkTime(t, format); err != nil {
+		return err
+	}
+	start := enc.offset()
+	enc.writeSep()
+	enc.writeByte('"')
+	enc.writeKey(key)
+	enc.writeObjKey(objKey)
+	enc.record(enc.writeTimeFormat(t, format, layout), start)
+	return nil
+}
+
+// checkTime returns an error, also set on the Encoder, if t cannot be encoded in format
+// because its number of milliseconds or microseconds since the Unix epoch overflows an int64.
+// It is called before anything is written for t.
+func (enc *Encoder) checkTime(t time.Time, format TimeFormat) error {
+	var unit int64
+	switch format {
+	case TimeUnixMilli:
+		unit = 1e3
+	case TimeUnixMicro:
+		unit = 1e6
+	default:
+		return nil
+	}
+	if s := t.Unix(); s >= math.MinInt64/unit && s <= (math.MaxInt64-(unit-1))/unit {
+		return nil
+	}
+	err := InvalidTypeError(
+		fmt.Sprintf("Cannot marshal time %s, it overflows an int64 in the selected TimeFormat", t.Format(time.RFC3339Nano)),
+	)
+	enc.SetError(err)
+	return err
+}
+
+// writeTimeFormat writes t in format, formatted with layout if format is TimeString,
+// and returns the Kind of the value written.
+func (enc *Encoder) writeTimeFormat(t time.Time, format TimeFormat, layout string) Kind {
+	switch format {
+	case TimeUnix:
+		enc.buf = strconv.AppendInt(enc.buf, t.Unix(), 10)
+	case TimeUnixMilli:
+		enc.buf = strconv.AppendInt(enc.buf, t.Unix()*1e3+int64(t.Nanosecond()/1e6), 10)
+	case TimeUnixMicro:
+		enc.buf = strconv.AppendInt(enc.buf, t.Unix()*1e6+int64(t.Nanosecond()/1e3), 10)
+	default:
+		enc.writeByte('"')
+		enc.writeTime(t, layout)
+		enc.writeByte('"')
+		return KindString
+	}
+	return KindNumber
+}
+
+// writeTime writes t formatted with layout, escaping the result if the layout produced chars to escape.
+func (enc *Encoder) writeTime(t time.Time, layout string) {
+	if layout == "" {
+		layout = enc.timeLayout
+		if layout == "" {
+			layout = time.RFC3339Nano
+		}
+	}
+	start := len(enc.buf)
+	enc.buf = t.AppendFormat(enc.buf, layout)
+	table := enc.getEscapeTable()
+	for _, c := range enc.buf[start:] {
+		if table[c] {
+			s := string(enc.buf[start:])
+			enc.buf = enc.buf[:start]
+			enc.writeStringEscape(s)
+			return
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package gojay
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTimeFloatSeconds struct {
+	t     *time.Time
+	epoch time.Time
+}
+
+func (t *testTimeFloatSeconds) IsNil() bool {
+	return t == nil
+}
+
+func (t *testTimeFloatSeconds) MarshalObject(enc *Encoder) {
+	enc.AddTimeFloatSecondsKey("t", t.t, t.epoch)
+}
+
+func TestEncoderTimeFloatSeconds(t *testing.T) {
+	epoch := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	after := epoch.Add(90*time.Second + 250*time.Millisecond)
+	before := epoch.Add(-1500 * time.Millisecond)
+	testCases := []struct {
+		name     string
+		t        *time.Time
+		expected string
+	}{
+		{
+			name:     "after-epoch",
+			t:        &after,
+			expected: `{"t":90.25}`,
+		},
+		{
+			name:     "before-epoch",
+			t:        &before,
+			expected: `{"t":-1.5}`,
+		},
+		{
+			name:     "nil",
+			expected: `{}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r, err := MarshalObject(&testTimeFloatSeconds{testCase.t, epoch})
+			assert.Nil(t, err, "Error should be nil")
+			assert.Equal(t, testCase.expected, string(r), "Result of marshalling is different as the one expected")
+		})
+	}
+}
+
+func TestEncoderTimeFloatSecondsOutOfRange(t *testing.T) {
+	epoch := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	far := time.Date(2500, 1, 1, 0, 0, 0, 0, time.UTC)
+	r, err := MarshalObject(&testTimeFloatSeconds{t: &far, epoch: epoch})
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+	assert.Nil(t, r, "result should be nil")
+	_, err = MarshalArray(EncodeArrayFunc(func(enc *Encoder) {
+		enc.AddTimeFloatSeconds(&far, epoch)
+	}))
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+}
+
+func TestEncoderTimeFloatSecondsArray(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	t1 := epoch.Add(time.Second)
+	t2 := epoch.Add(2500 * time.Millisecond)
+	r, err := MarshalArray(EncodeArrayFunc(func(enc *Encoder) {
+		enc.AddTimeFloatSeconds(&t1, epoch)
+		enc.AddTimeFloatSeconds(nil, epoch)
+		enc.AddTimeFloatSeconds(&t2, epoch)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `[1,2.5]`, string(r), "Result of marshalling is different as the one expected")
+}
+
+func TestEncoderTimeLayout(t *testing.T) {
+	ts := time.Date(2018, 4, 12, 10, 30, 15, 123000000, time.UTC)
+	v := EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddTimeKey("default", ts, "")
+		enc.AddTimeKey("date", ts, "2006-01-02")
+		enc.AddTimeKey("quoted", ts, `"Jan" 2`)
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddTime(ts, time.Kitchen)
+			enc.AddTime(ts, "")
+		}))
+	})
+	r, err := MarshalObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"default":"2018-04-12T10:30:15.123Z","date":"2018-04-12","quoted":"\"Apr\" 12",`+
+			`"arr":["10:30AM","2018-04-12T10:30:15.123Z"]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetTimeLayout(time.RFC1123)
+	err = enc.AddObject(v)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"default":"Thu, 12 Apr 2018 10:30:15 UTC","date":"2018-04-12","quoted":"\"Apr\" 12",`+
+			`"arr":["10:30AM","Thu, 12 Apr 2018 10:30:15 UTC"]}`,
+		string(enc.Bytes()),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderTimeUnix(t *testing.T) {
+	ts := time.Date(2018, 4, 12, 10, 30, 15, 123456789, time.UTC)
+	before := time.Unix(-2, 500000000)
+	r, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddTimeUnixKey("s", ts)
+		enc.AddTimeUnixMilliKey("ms", ts)
+		enc.AddTimeUnixMilliKey("before", before)
+		enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+			enc.AddTimeUnix(ts)
+			enc.AddTimeUnixMilli(ts)
+		}))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(
+		t,
+		`{"s":1523529015,"ms":1523529015123,"before":-1500,"arr":[1523529015,1523529015123]}`,
+		string(r),
+		"Result of marshalling is different as the one expected")
+}
+
+func TestEncoderSetTimeFormat(t *testing.T) {
+	ts := time.Date(2018, 4, 12, 10, 30, 15, 123456789, time.UTC)
+	testCases := []struct {
+		format   TimeFormat
+		expected string
+	}{
+		{TimeString, `{"t":"2018-04-12","arr":["2018-04-12T10:30:15.123456789Z"]}`},
+		{TimeUnix, `{"t":1523529015,"arr":[1523529015]}`},
+		{TimeUnixMilli, `{"t":1523529015123,"arr":[1523529015123]}`},
+		{TimeUnixMicro, `{"t":1523529015123456,"arr":[1523529015123456]}`},
+	}
+	for _, testCase := range testCases {
+		enc := NewEncoder()
+		enc.SetTimeFormat(testCase.format)
+		err := enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddTimeKey("t", ts, "2006-01-02")
+			enc.AddArrayKey("arr", EncodeArrayFunc(func(enc *Encoder) {
+				enc.AddTime(ts, "")
+			}))
+		}))
+		assert.Nil(t, err, "Error should be nil")
+		assert.Equal(t, testCase.expected, string(enc.Bytes()), "Result of marshalling is different as the one expected")
+		enc.addToPool()
+	}
+}
+
+func TestEncoderTimeUnixOverflow(t *testing.T) {
+	far := time.Unix(math.MaxInt64/1000+1, 0)
+	_, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddTimeUnixMilliKey("t", far)
+	}))
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+
+	enc := NewEncoder()
+	defer enc.addToPool()
+	enc.SetTimeFormat(TimeUnixMicro)
+	err = enc.AddObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddTimeKey("t", time.Unix(math.MaxInt64/1000000+1, 0), "")
+	}))
+	assert.IsType(t, InvalidTypeError(""), err, "err should be of type InvalidTypeError")
+
+	r, err := MarshalArray(EncodeArrayFunc(func(enc *Encoder) {
+		enc.AddTimeUnix(far)
+		enc.AddTimeUnixMilli(time.Unix(math.MinInt64/1000, 0))
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, fmt.Sprintf("[%d,%d]", far.Unix(), int64(math.MinInt64/1000*1000)), string(r), "Result of marshalling is different as the one expected")
+}
@@ -1,5 +1,7 @@
 package gojay
 
+import "fmt"
+
 // InvalidJSONError is a type representing an error returned when
 // Decoding encounters invalid JSON.
 type InvalidJSONError string
@@ -33,3 +35,52 @@ type NoReaderError string
 func (err NoReaderError) Error() string {
 	return string(err)
 }
+
+// NoWriterError is a type representing an error returned when
+// encoding requires a writer and none was given
+type NoWriterError string
+
+func (err NoWriterError) Error() string {
+	return string(err)
+}
+
+// RollbackError is a type representing an error returned when
+// an Encoder cannot be rolled back to a Checkpoint.
+type RollbackError string
+
+func (err RollbackError) Error() string {
+	return string(err)
+}
+
+// LimitExceededError is a type representing an error returned when
+// Decoding reads a value exceeding one of the limits set on the Decoder.
+type LimitExceededError string
+
+func (err LimitExceededError) Error() string {
+	return string(err)
+}
+
+// TypeMismatchError is a type representing an error returned when
+// the top level JSON value is not of the kind expected by the decoding entry point,
+// for example when an array is given to UnmarshalObject.
+type TypeMismatchError struct {
+	Expected Kind
+	Actual   Kind
+	Offset   int
+}
+
+func (err *TypeMismatchError) Error() string {
+	return fmt.Sprintf("Cannot unmarshal, expected %s got %s at pos %d", err.Expected, err.Actual, err.Offset)
+}
+
+// FieldError is a type representing an error returned while decoding the value of an object field,
+// recorded by a Decoder set to continue on error.
+type FieldError struct {
+	Key    string
+	Offset int
+	Err    error
+}
+
+func (err *FieldError) Error() string {
+	return fmt.Sprintf("Cannot decode key %q at pos %d: %s", err.Key, err.Offset, err.Err)
+}
@@ -0,0 +1,49 @@
+package gojay
+
+// Kind is the kind of a JSON value.
+type Kind int
+
+// Kinds of JSON values.
+const (
+	KindString Kind = iota + 1
+	KindNumber
+	KindBool
+	KindObject
+	KindArray
+	KindNull
+)
+
+var kindNames = [...]string{
+	KindString: "string",
+	KindNumber: "number",
+	KindBool:   "bool",
+	KindObject: "object",
+	KindArray:  "array",
+	KindNull:   "null",
+}
+
+func (k Kind) String() string {
+	if k > 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "invalid"
+}
+
+// kindOf returns the Kind of the JSON value starting with char c.
+func kindOf(c byte) Kind {
+	switch c {
+	case '"':
+		return KindString
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return KindNumber
+	case 't', 'f':
+		return KindBool
+	case '{':
+		return KindObject
+	case '[':
+		return KindArray
+	case 'n':
+		return KindNull
+	}
+	return 0
+}
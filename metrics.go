@@ -0,0 +1,69 @@
+package gojay
+
+import "sync/atomic"
+
+// PoolKind tells whether a metric is about Encoders or Decoders.
+type PoolKind int
+
+// Kinds of pools.
+const (
+	// PoolEncoder is the pool of the Encoders and their buffers
+	PoolEncoder PoolKind = iota
+	// PoolDecoder is the pool of the Decoders and their buffers
+	PoolDecoder
+)
+
+// MetricsHook receives the events of the pools and buffers of the package, see SetMetricsHook.
+//
+// Its methods are called synchronously while values are encoded and decoded, possibly from several goroutines
+// at once, so they must be fast and safe for concurrent use, such as incrementing Prometheus counters.
+// NopMetricsHook can be embedded to only implement some of them.
+type MetricsHook interface {
+	// PoolGet is called when an Encoder or a Decoder is taken, hit reporting whether it was borrowed from the pool
+	PoolGet(kind PoolKind, hit bool)
+	// BufferGrow is called when a Decoder reallocates its buffer to capacity bytes because a value did not fit,
+	// and when an encoding returned by a Marshal function outgrew the EncoderBufferSize of the PoolConfig,
+	// capacity being then the capacity its buffer ended with. Frequent calls tell that the buffer sizes are too small.
+	BufferGrow(kind PoolKind, capacity int)
+	// BufferDropped is called when a buffer of capacity bytes exceeding the MaxBufferSize of the PoolConfig
+	// is released instead of being pooled
+	BufferDropped(kind PoolKind, capacity int)
+	// Encoded is called with the size of each encoding returned by the Marshal functions,
+	// and of each chunk flushed by an Encoder to its io.Writer
+	Encoded(n int)
+}
+
+// NopMetricsHook is a MetricsHook ignoring all the events.
+type NopMetricsHook struct{}
+
+// PoolGet implements MetricsHook.
+func (NopMetricsHook) PoolGet(kind PoolKind, hit bool) {}
+
+// BufferGrow implements MetricsHook.
+func (NopMetricsHook) BufferGrow(kind PoolKind, capacity int) {}
+
+// BufferDropped implements MetricsHook.
+func (NopMetricsHook) BufferDropped(kind PoolKind, capacity int) {}
+
+// Encoded implements MetricsHook.
+func (NopMetricsHook) Encoded(n int) {}
+
+// metricsHook holds the metricsHookBox set by SetMetricsHook
+var metricsHook atomic.Value
+
+// metricsHookBox wraps the hook so that a nil hook can be stored in metricsHook
+type metricsHookBox struct {
+	hook MetricsHook
+}
+
+// SetMetricsHook sets the hook receiving the events of the pools and buffers, nil removes it.
+// Unlike SetPoolConfig, it is safe to call while values are encoded or decoded.
+func SetMetricsHook(hook MetricsHook) {
+	metricsHook.Store(metricsHookBox{hook})
+}
+
+// metrics returns the hook set by SetMetricsHook, or nil.
+func metrics() MetricsHook {
+	box, _ := metricsHook.Load().(metricsHookBox)
+	return box.hook
+}
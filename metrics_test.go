@@ -0,0 +1,84 @@
+package gojay
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMetricsHook struct {
+	NopMetricsHook
+	mu      sync.Mutex
+	hits    map[PoolKind]int
+	misses  map[PoolKind]int
+	grows   map[PoolKind]int
+	dropped int
+	encoded int
+}
+
+func (h *testMetricsHook) PoolGet(kind PoolKind, hit bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if hit {
+		h.hits[kind]++
+		return
+	}
+	h.misses[kind]++
+}
+
+func (h *testMetricsHook) BufferGrow(kind PoolKind, capacity int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.grows[kind]++
+}
+
+func (h *testMetricsHook) BufferDropped(kind PoolKind, capacity int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.dropped++
+}
+
+func (h *testMetricsHook) Encoded(n int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.encoded += n
+}
+
+func TestSetMetricsHook(t *testing.T) {
+	defer SetPoolConfig(DefaultPoolConfig())
+	defer SetMetricsHook(nil)
+	SetPoolConfig(PoolConfig{Size: 2, EncoderBufferSize: 16, DecoderBufferSize: 8, MaxBufferSize: 64})
+	h := &testMetricsHook{
+		hits:   map[PoolKind]int{},
+		misses: map[PoolKind]int{},
+		grows:  map[PoolKind]int{},
+	}
+	SetMetricsHook(h)
+
+	for _, s := range []string{"a", strings.Repeat("b", 20)} {
+		_, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+			enc.AddStringKey("s", s)
+		}))
+		assert.Nil(t, err, "Error should be nil")
+	}
+	assert.Equal(t, 1, h.misses[PoolEncoder], "first encoder should be a pool miss")
+	assert.Equal(t, 1, h.hits[PoolEncoder], "second encoder should be a pool hit")
+	assert.Equal(t, 1, h.grows[PoolEncoder], "encoding larger than EncoderBufferSize should be reported")
+	assert.Equal(t, len(`{"s":"a"}`)+len(`{"s":"`)+20+len(`"}`), h.encoded, "encoded bytes should be counted")
+
+	dec := NewDecoder(strings.NewReader(`"` + strings.Repeat("a", 100) + `"`))
+	var s string
+	err := dec.Decode(&s)
+	assert.Nil(t, err, "Error should be nil")
+	dec.addToPool()
+	assert.Equal(t, 1, h.misses[PoolDecoder], "first decoder should be a pool miss")
+	assert.True(t, h.grows[PoolDecoder] > 0, "decoder buffer grows should be reported")
+	assert.Equal(t, 1, h.dropped, "buffer larger than MaxBufferSize should be reported")
+
+	SetMetricsHook(nil)
+	_, err = MarshalObject(EncodeObjectFunc(func(enc *Encoder) {}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, 1, h.hits[PoolEncoder], "removed hook should not be called")
+}
@@ -0,0 +1,29 @@
+package gojay
+
+// MustMarshalObject is like MarshalObject but panics if v cannot be encoded, the panic value being the error.
+// It is meant for tests, fixtures and values built at init time, where an error is a programming error.
+func MustMarshalObject(v MarshalerObject) []byte {
+	b, err := MarshalObject(v)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// MustMarshal is like Marshal but panics if v cannot be encoded, the panic value being the error.
+// It is meant for tests, fixtures and values built at init time, where an error is a programming error.
+func MustMarshal(v interface{}) []byte {
+	b, err := Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// MustUnmarshal is like Unmarshal but panics if data cannot be decoded to v, the panic value being the error.
+// It is meant for tests, fixtures and values built at init time, where an error is a programming error.
+func MustUnmarshal(data []byte, v interface{}) {
+	if err := Unmarshal(data, v); err != nil {
+		panic(err)
+	}
+}
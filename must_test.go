@@ -0,0 +1,32 @@
+package gojay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMustMarshal(t *testing.T) {
+	assert.Equal(t, `{"id":1,"name":"a"}`, string(MustMarshalObject(&testLineEvent{1, "a"})), "Result of marshalling is different as the one expected")
+	assert.Equal(t, `"str"`, string(MustMarshal("str")), "Result of marshalling is different as the one expected")
+	assert.Panics(t, func() {
+		MustMarshalObject(EncodeObjectFunc(func(enc *Encoder) { enc.SetError(errTestMarshal) }))
+	}, "MustMarshalObject should panic on error")
+	assert.Panics(t, func() { MustMarshal(make(chan int)) }, "MustMarshal should panic on error")
+
+	defer func() {
+		assert.IsType(t, InvalidTypeError(""), recover(), "panic value should be the error")
+	}()
+	MustMarshal(make(chan int))
+}
+
+func TestMustUnmarshal(t *testing.T) {
+	v := &testClickEvent{}
+	MustUnmarshal([]byte(`{"x":1,"y":2}`), v)
+	assert.Equal(t, testClickEvent{1, 2}, *v, "v should be decoded")
+	var s string
+	MustUnmarshal([]byte(`"str"`), &s)
+	assert.Equal(t, "str", s, "s should be decoded")
+	assert.Panics(t, func() { MustUnmarshal([]byte(`{"x":"a"}`), v) }, "MustUnmarshal should panic on error")
+	assert.Panics(t, func() { MustUnmarshal([]byte(`1`), &s) }, "MustUnmarshal should panic on error")
+}
@@ -0,0 +1,59 @@
+package gojay
+
+// PoolConfig configures the pools the Encoders and Decoders are borrowed from, see SetPoolConfig.
+type PoolConfig struct {
+	// Size is the number of Encoders, and of Decoders, kept in their pool for reuse, 0 disables pooling
+	Size int
+	// EncoderBufferSize is the initial capacity of the buffer MarshalObject and MarshalArray encode to
+	EncoderBufferSize int
+	// DecoderBufferSize is the size of the buffer of the Decoders reading from an io.Reader
+	DecoderBufferSize int
+	// MaxBufferSize is the capacity above which the buffer of a Decoder, or a scratch buffer of the encoding
+	// functions, is released instead of being kept in a pool, 0 for no limit
+	MaxBufferSize int
+}
+
+// poolConfig is the configuration of the pools, set by SetPoolConfig
+var poolConfig = DefaultPoolConfig()
+
+// poolableBuffer reports whether a buffer of capacity bytes can be pooled under the MaxBufferSize of the PoolConfig,
+// reporting it to the MetricsHook as dropped otherwise.
+func poolableBuffer(kind PoolKind, capacity int) bool {
+	if poolConfig.MaxBufferSize > 0 && capacity > poolConfig.MaxBufferSize {
+		if m := metrics(); m != nil {
+			m.BufferDropped(kind, capacity)
+		}
+		return false
+	}
+	return true
+}
+
+// DefaultPoolConfig returns the configuration of the pools unless SetPoolConfig is called.
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		Size:              16,
+		EncoderBufferSize: 200,
+		DecoderBufferSize: 512,
+	}
+}
+
+// SetPoolConfig replaces the pools of Encoders and Decoders with pools configured by c,
+// the Encoders and Decoders pooled so far are dropped.
+// A DecoderBufferSize lower than 1 is replaced by the default one.
+//
+// It is not safe to call SetPoolConfig while values are encoded or decoded,
+// it is meant to be called once when the program starts.
+func SetPoolConfig(c PoolConfig) {
+	if c.Size < 0 {
+		c.Size = 0
+	}
+	if c.EncoderBufferSize < 0 {
+		c.EncoderBufferSize = 0
+	}
+	if c.DecoderBufferSize < 1 {
+		c.DecoderBufferSize = DefaultPoolConfig().DecoderBufferSize
+	}
+	poolConfig = c
+	encObjPool = make(chan *Encoder, c.Size)
+	decPool = make(chan *Decoder, c.Size)
+}
@@ -0,0 +1,72 @@
+package gojay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetPoolConfig(t *testing.T) {
+	defer SetPoolConfig(DefaultPoolConfig())
+	SetPoolConfig(PoolConfig{Size: 2, EncoderBufferSize: 4096, DecoderBufferSize: 8, MaxBufferSize: 64})
+
+	b, err := MarshalObject(EncodeObjectFunc(func(enc *Encoder) {
+		enc.AddIntKey("a", 1)
+	}))
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, `{"a":1}`, string(b), "Result of marshalling is different as the one expected")
+	assert.Equal(t, 4096, cap(b), "buffer should have the configured capacity")
+
+	dec := NewDecoder(strings.NewReader(`"` + strings.Repeat("a", 100) + `"`))
+	assert.Len(t, dec.data, 8, "buffer should have the configured size")
+	var s string
+	err = dec.Decode(&s)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, strings.Repeat("a", 100), s, "string should be decoded")
+	dec.addToPool()
+	assert.Nil(t, dec.data, "buffer larger than MaxBufferSize should not be kept")
+}
+
+func TestSetPoolConfigNoPooling(t *testing.T) {
+	defer SetPoolConfig(DefaultPoolConfig())
+	SetPoolConfig(PoolConfig{Size: 0, DecoderBufferSize: -1})
+	assert.Equal(t, DefaultPoolConfig().DecoderBufferSize, poolConfig.DecoderBufferSize, "invalid size should be replaced by the default one")
+
+	enc := BorrowEncoder()
+	enc.Release()
+	assert.True(t, BorrowEncoder() != enc, "Encoder should not be pooled")
+	dec := NewDecoder(strings.NewReader("1"))
+	dec.addToPool()
+	assert.True(t, NewDecoder(strings.NewReader("1")) != dec, "Decoder should not be pooled")
+}
+
+func TestDecoderPoolReusesBuffer(t *testing.T) {
+	defer SetPoolConfig(DefaultPoolConfig())
+	SetPoolConfig(PoolConfig{Size: 2, EncoderBufferSize: 16, DecoderBufferSize: 8, MaxBufferSize: 1024})
+
+	dec := NewDecoder(strings.NewReader(`"` + strings.Repeat("a", 100) + `"`))
+	var s string
+	err := dec.Decode(&s)
+	assert.Nil(t, err, "Error should be nil")
+	buf := dec.data[:1]
+	dec.addToPool()
+
+	dec = NewDecoder(strings.NewReader(`"` + strings.Repeat("b", 100) + `"`))
+	assert.True(t, &dec.data[0] == &buf[0], "buffer of the pooled decoder should be reused")
+	assert.Len(t, dec.data, 8, "buffer should have the configured size")
+	err = dec.Decode(&s)
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, strings.Repeat("b", 100), s, "string should be decoded")
+	assert.True(t, &dec.data[0] == &buf[0], "buffer should not grow past its capacity")
+	dec.addToPool()
+
+	input := []byte(`"abc"`)
+	dec = newDecoder(nil, 0)
+	dec.data = input
+	dec.length = len(input)
+	err = dec.Decode(&s)
+	assert.Nil(t, err, "Error should be nil")
+	dec.addToPool()
+	assert.Nil(t, dec.data, "input of a decoder without io.Reader should not be kept")
+}